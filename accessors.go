@@ -0,0 +1,103 @@
+package version
+
+import (
+	"runtime"
+	"strings"
+)
+
+// ShortCommitLength is the number of characters that git abbreviates commit hashes to by default.
+const ShortCommitLength = 7
+
+// Executable returns the name of the currently executing binary, with the same fallback that Details uses.
+func Executable() string {
+	return Current().Executable
+}
+
+// ExecutablePath returns the absolute path of the currently executing binary, as returned by 'os.Executable()', or
+// 'unknown' if it cannot be determined. Unlike Executable, this tells apart several installed copies of one binary.
+func ExecutablePath() string {
+	exePath, err := runtimeLookups().executablePath()
+	if err != nil {
+		return unknownValue
+	}
+
+	return exePath
+}
+
+// Hostname returns the name of the host that the currently executing binary is running on, as returned by
+// 'os.Hostname()', or 'unknown' if it cannot be determined.
+func Hostname() string {
+	name, err := osHostname()
+	if err != nil {
+		return unknownValue
+	}
+
+	return name
+}
+
+// Version returns the semver-compatible git tag that this binary was built from, with the same fallback that Details
+// uses. Any leading 'v' is kept, as-is; use VersionNumber to have it removed.
+func Version() string {
+	return Current().Version
+}
+
+// VersionNumber returns the same value as Version, with any leading 'v' or 'V' removed.
+// Prerelease and build metadata are left intact, so the default fallback becomes '0.0.0-unknown'.
+func VersionNumber() string {
+	return trimVPrefix(Version())
+}
+
+// BuiltBy returns the name of the user that built the currently executing binary, with the same fallback that Details
+// uses.
+func BuiltBy() string {
+	return Current().BuiltBy
+}
+
+// Commit returns the hash of the commit that this binary was built from, with the same fallback that Details uses.
+func Commit() string {
+	return Current().Commit
+}
+
+// ShortCommit returns the first n characters of the hash from Commit, preserving any '-dirty' suffix.
+// If n is zero or less, or not shorter than the hash, or the commit is not a hash, such as when it is unknown, the
+// commit is returned unchanged. Pass ShortCommitLength to abbreviate the hash as git would.
+func ShortCommit(n int) string {
+	return shortCommit(Commit(), n)
+}
+
+// BuiltWith returns the version of the Go toolchain that built the binary, with the same fallback that Details uses.
+// This is the value recorded at build time, either with ldflags or in build info, whereas RuntimeGoVersion reports the
+// version of the Go runtime that is actually linked into the binary.
+func BuiltWith() string {
+	return Current().BuiltWith
+}
+
+// RuntimeGoVersion returns the version of the Go runtime linked into the currently executing binary, as reported by
+// 'runtime.Version()'. A mismatch with BuiltWith means that the recorded toolchain is not the one that was used.
+func RuntimeGoVersion() string {
+	return runtime.Version()
+}
+
+// BuildDate returns the build timestamp of the currently executing binary, with the same fallback that Details uses.
+func BuildDate() string {
+	return Current().BuildDate
+}
+
+// OS returns the operating system that the binary was compiled for.
+func OS() string {
+	return runtimeGOOS
+}
+
+// Arch returns the architecture that the binary was compiled for.
+func Arch() string {
+	return runtime.GOARCH
+}
+
+// trimVPrefix removes a single leading 'v' or 'V' from the given version.
+func trimVPrefix(version string) string {
+	if strings.HasPrefix(version, "v") || strings.HasPrefix(version, "V") {
+		return version[1:]
+	}
+
+	return version
+}
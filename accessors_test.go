@@ -0,0 +1,252 @@
+package version_test
+
+import (
+	"errors"
+	"io/fs"
+	"os/user"
+	"runtime"
+	"runtime/debug"
+	"testing"
+
+	"go.jlucktay.dev/version"
+)
+
+//nolint:paralleltest // Overrides the package seams.
+func TestAccessors(t *testing.T) {
+	stubRuntime(t)
+
+	for _, check := range []struct {
+		name string
+		got  func() string
+		want string
+	}{
+		{"Executable", version.Executable, testExecutable},
+		{"Version", version.Version, testVersion},
+		{"BuiltBy", version.BuiltBy, testUser},
+		{"Commit", version.Commit, testRevision},
+		{"BuiltWith", version.BuiltWith, testGoVersion},
+		{"BuildDate", version.BuildDate, testBuildDate},
+		{"OS", version.OS, runtime.GOOS},
+		{"Arch", version.Arch, runtime.GOARCH},
+	} {
+		if got := check.got(); got != check.want {
+			t.Errorf("%s() = %q, want %q", check.name, got, check.want)
+		}
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestAccessorsMatchCurrent(t *testing.T) {
+	stubRuntime(t)
+	version.SetBuiltBy("explicit")
+
+	info := version.Current()
+
+	if got := version.BuiltBy(); got != info.BuiltBy {
+		t.Errorf("BuiltBy() = %q, want %q as in Current()", got, info.BuiltBy)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestShortCommit(t *testing.T) {
+	stubRuntime(t)
+
+	for n, want := range map[int]string{
+		version.ShortCommitLength: "0123456",
+		12:                        "0123456789ab",
+		0:                         testRevision,
+		-1:                        testRevision,
+		len(testRevision):         testRevision,
+		100:                       testRevision,
+	} {
+		if got := version.ShortCommit(n); got != want {
+			t.Errorf("ShortCommit(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestShortCommitKeepsDirtySuffix(t *testing.T) {
+	stubRuntime(t)
+	stubBuildInfo(t, func(bi *debug.BuildInfo) {
+		bi.Settings[len(bi.Settings)-1].Value = "true"
+	})
+
+	if got, want := version.ShortCommit(version.ShortCommitLength), "0123456-dirty"; got != want {
+		t.Errorf("ShortCommit() = %q, want %q", got, want)
+	}
+
+	version.SetCommit("abc-dirty")
+
+	if got, want := version.ShortCommit(version.ShortCommitLength), "abc-dirty"; got != want {
+		t.Errorf("ShortCommit() of a commit shorter than n = %q, want %q", got, want)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestRuntimeGoVersionIsIndependentOfBuiltWith(t *testing.T) {
+	stubRuntime(t)
+	version.SetBuiltWith("go1.10")
+
+	if got := version.RuntimeGoVersion(); got != runtime.Version() {
+		t.Errorf("RuntimeGoVersion() = %q, want %q", got, runtime.Version())
+	}
+
+	info := version.Current()
+
+	if info.BuiltWith != "go1.10" || info.RuntimeGoVersion != runtime.Version() {
+		t.Errorf("Current() builtWith and runtimeGoVersion = %q and %q, want %q and %q",
+			info.BuiltWith, info.RuntimeGoVersion, "go1.10", runtime.Version())
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestVersionNumber(t *testing.T) {
+	stubRuntime(t)
+
+	for set, want := range map[string]string{
+		"":              "1.2.3",
+		"v2.0.0":        "2.0.0",
+		"V2.0.0":        "2.0.0",
+		"2.0.0":         "2.0.0",
+		"vv2.0.0":       "v2.0.0",
+		"v2.0.0-rc.1+b": "2.0.0-rc.1+b",
+	} {
+		version.SetVersion(set)
+
+		if got := version.VersionNumber(); got != want {
+			t.Errorf("VersionNumber() with version %q = %q, want %q", set, got, want)
+		}
+
+		if set != "" && version.Version() != set {
+			t.Errorf("Version() = %q, want %q left as-is", version.Version(), set)
+		}
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestVersionNumberOfFallback(t *testing.T) {
+	stubBuildInfo(t, func(bi *debug.BuildInfo) {
+		bi.Main.Version = ""
+	})
+
+	if got, want := version.VersionNumber(), "0.0.0-unknown"; got != want {
+		t.Errorf("VersionNumber() = %q, want %q", got, want)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestExecutablePath(t *testing.T) {
+	stubRuntime(t)
+
+	if got := version.ExecutablePath(); got != testExePath {
+		t.Errorf("ExecutablePath() = %q, want %q", got, testExePath)
+	}
+
+	if got := version.Current(version.WithExecutablePath()).ExecutablePath; got != testExePath {
+		t.Errorf("Current(WithExecutablePath()).ExecutablePath = %q, want %q", got, testExePath)
+	}
+
+	if got := version.Current().ExecutablePath; got != "" {
+		t.Errorf("Current().ExecutablePath = %q, want it left out by default", got)
+	}
+
+	if got := version.Current(version.WithExecutablePath()).Executable; got != testExecutable {
+		t.Errorf("Current(WithExecutablePath()).Executable = %q, want the base name %q", got, testExecutable)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestExecutablePathFallsBack(t *testing.T) {
+	stubRuntime(t)
+	version.Stub(t, version.OSExecutable, func() (string, error) {
+		return "", fs.ErrNotExist
+	})
+
+	if got := version.ExecutablePath(); got != "unknown" {
+		t.Errorf("ExecutablePath() = %q, want %q", got, "unknown")
+	}
+
+	if got := version.Current(version.WithExecutablePath()).ExecutablePath; got != "unknown" {
+		t.Errorf("Current(WithExecutablePath()).ExecutablePath = %q, want %q", got, "unknown")
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestHostname(t *testing.T) {
+	stubRuntime(t)
+
+	if got := version.Hostname(); got != testHostname {
+		t.Errorf("Hostname() = %q, want %q", got, testHostname)
+	}
+
+	if got := version.Current(version.WithHostname()).Hostname; got != testHostname {
+		t.Errorf("Current(WithHostname()).Hostname = %q, want %q", got, testHostname)
+	}
+
+	if got := version.Current().Hostname; got != "" {
+		t.Errorf("Current().Hostname = %q, want it left out by default", got)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestHostnameFallsBack(t *testing.T) {
+	stubRuntime(t)
+	version.Stub(t, version.OSHostname, func() (string, error) {
+		return "", fs.ErrPermission
+	})
+
+	if got := version.Hostname(); got != "unknown" {
+		t.Errorf("Hostname() = %q, want %q", got, "unknown")
+	}
+
+	if got := version.Current(version.WithHostname()).Hostname; got != "unknown" {
+		t.Errorf("Current(WithHostname()).Hostname = %q, want %q", got, "unknown")
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestBuiltByFallsBackToUID(t *testing.T) {
+	testCases := map[string]struct {
+		uid  int
+		want string
+	}{
+		"regular user": {uid: 1000, want: "uid:1000"},
+		"root":         {uid: 0, want: "uid:0"},
+		"no user IDs":  {uid: -1, want: "unknown"},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(name, func(t *testing.T) {
+			stubRuntime(t)
+			version.Stub(t, version.UserCurrent, func() (*user.User, error) {
+				return nil, user.UnknownUserIdError(testCase.uid)
+			})
+			version.Stub(t, version.OSGetuid, func() int {
+				return testCase.uid
+			})
+
+			want := testCase.want
+			if runtime.GOOS == "windows" {
+				want = "unknown"
+			}
+
+			if got := version.BuiltBy(); got != want {
+				t.Errorf("BuiltBy() = %q, want %q", got, want)
+			}
+
+			// The failed lookup is still reported, whether or not the user ID stood in for the name.
+			info, errs := version.InfoWithErrors()
+			if info.BuiltBy != want {
+				t.Errorf("InfoWithErrors().BuiltBy = %q, want %q", info.BuiltBy, want)
+			}
+
+			var unknownUser user.UnknownUserIdError
+			if len(errs) != 1 || !errors.Is(errs[0], version.ErrUserLookup) || !errors.As(errs[0], &unknownUser) {
+				t.Errorf("InfoWithErrors() errors = %v, want just the wrapped user lookup failure", errs)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package version
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Banner returns a multi-line banner for startup logs, with the executable name and version inside a box-drawn border
+// followed by the commit and build date, as in:
+//
+//	┌──────────────┐
+//	│ myapp v1.2.3 │
+//	└──────────────┘
+//	commit 0123456789abcdef0123456789abcdef01234567
+//	built  2006-01-02T15:04:05Z
+//
+// The banner contains no ANSI escape codes, and uses the same fallbacks as Details.
+func Banner() string {
+	i := Current()
+
+	title := i.Executable + " " + i.Version
+	bar := strings.Repeat("─", utf8.RuneCountInString(title)+2)
+
+	return fmt.Sprintf("┌%s┐\n│ %s │\n└%s┘\ncommit %s\nbuilt  %s", bar, title, bar, i.Commit, i.BuildDate)
+}
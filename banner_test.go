@@ -0,0 +1,42 @@
+package version_test
+
+import (
+	"strings"
+	"testing"
+
+	"go.jlucktay.dev/version"
+)
+
+//nolint:paralleltest // Overrides the package seams.
+func TestBannerGolden(t *testing.T) {
+	stubRuntime(t)
+
+	assertGolden(t, "banner", []byte(version.Banner()))
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestBannerFitsWideTitles(t *testing.T) {
+	stubRuntime(t)
+	version.SetExecutable("ünïcödé")
+	version.SetVersion("v10.20.30-rc.1")
+
+	lines := strings.Split(version.Banner(), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("Banner() has %d lines, want 5:\n%s", len(lines), version.Banner())
+	}
+
+	if want := "│ ünïcödé v10.20.30-rc.1 │"; lines[1] != want {
+		t.Errorf("Banner() title = %q, want %q", lines[1], want)
+	}
+
+	// The border is as wide as the title in runes, not bytes.
+	for _, border := range []string{lines[0], lines[2]} {
+		if got, want := len([]rune(border)), len([]rune(lines[1])); got != want {
+			t.Errorf("Banner() border %q is %d runes wide, want %d", border, got, want)
+		}
+	}
+
+	if strings.Contains(version.Banner(), "\x1b[") {
+		t.Errorf("Banner() = %q, want no ANSI escape codes", version.Banner())
+	}
+}
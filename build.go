@@ -0,0 +1,218 @@
+package version
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+)
+
+// The shared Build backing the package-level functions.
+//
+//nolint:gochecknoglobals // Mirrors the symbols set with ldflags, which are themselves global.
+var std = &Build{seed: seed, host: true}
+
+// Config carries explicit values for a Build constructed with New, along with the fallback behaviours to use for any
+// values that are left empty.
+type Config struct {
+	// Explicit values, each of which takes precedence over the fallbacks if set.
+	Executable string
+	Version    string
+	BuiltBy    string
+	Commit     string
+	BuiltWith  string
+	BuildDate  string
+
+	// DateLayout is passed to WithDateLayout if set.
+	DateLayout string
+
+	// ShortCommit is passed to WithShortCommit if greater than zero.
+	ShortCommit int
+
+	// UTC enables WithUTC if set.
+	UTC bool
+
+	// Isolated stops any executable, version, commit, or builtBy value left empty from falling back to those of the
+	// host binary, such as for a library that should not report the identity of whatever embeds it.
+	Isolated bool
+}
+
+// Build describes a build of a binary, combining explicit values with the usual fallbacks.
+// Each Build is independent of any other, and is safe for concurrent use by multiple goroutines.
+type Build struct {
+	// seed returns the explicit values, before any fallbacks are applied.
+	seed func() Info
+
+	opts []Option
+
+	// host is set unless the Build is isolated from the host binary, and lets it fall back to the values identifying it.
+	host bool
+
+	// derived caches the Info derived with the options from the Config, until the explicit values next change.
+	derivedMu sync.Mutex
+	derived   *derivation
+}
+
+// Default returns the shared Build backing the package-level functions, which takes its values from the symbols set
+// with ldflags or the Set* functions, and from any version loaded with LoadVersionFrom.
+func Default() *Build {
+	return std
+}
+
+// New returns a Build that takes its values from the given Config, falling back to the environment and then the
+// runtime for any that are empty, following the same order of precedence as the package-level functions.
+// Set Isolated in the Config for a library reporting its own version alongside that of the binary embedding it, so
+// that the executable, version, commit, and builtBy values are not borrowed from the host binary, and any of them left
+// empty fall back to 'unknown', or whatever was given with WithUnknownValue. The builtWith and buildDate values
+// describe the binary that everything was compiled into, so they fall back to the runtime either way.
+func New(cfg Config) *Build {
+	explicit := Info{
+		Executable: cfg.Executable,
+		Version:    cfg.Version,
+		BuiltBy:    cfg.BuiltBy,
+		Commit:     cfg.Commit,
+		BuiltWith:  cfg.BuiltWith,
+		BuildDate:  cfg.BuildDate,
+	}
+
+	b := &Build{
+		seed: func() Info {
+			return explicit
+		},
+		host: !cfg.Isolated,
+	}
+
+	if cfg.DateLayout != "" {
+		b.opts = append(b.opts, WithDateLayout(cfg.DateLayout))
+	}
+
+	if cfg.ShortCommit > 0 {
+		b.opts = append(b.opts, WithShortCommit(cfg.ShortCommit))
+	}
+
+	if cfg.UTC {
+		b.opts = append(b.opts, WithUTC())
+	}
+
+	return b
+}
+
+// Info returns the Info describing the build, as with the package-level Current function.
+// Any options given are applied after those from the Config.
+func (b *Build) Info(opts ...Option) Info {
+	i, _ := b.derive(b.options(opts))
+
+	return i
+}
+
+// InfoWithErrors returns the Info describing the build along with any lookup errors, as with the package-level
+// InfoWithErrors function.
+func (b *Build) InfoWithErrors(opts ...Option) (Info, []error) {
+	return b.derive(b.options(opts))
+}
+
+// Details returns a string describing the build, as with the package-level Details function.
+func (b *Build) Details(opts ...Option) string {
+	o := b.detailsOptions(opts)
+	i, _ := b.derive(o)
+	format, args := o.formatArgs(o.colorize(i, os.Stdout))
+
+	return fmt.Sprintf(format, args...)
+}
+
+// Short returns a terse string identifying the build, as with the package-level Short function.
+// The commit is abbreviated to ShortCommitLength characters unless the Config asks for a different length.
+func (b *Build) Short() string {
+	opts := append([]Option{WithShortCommit(ShortCommitLength)}, b.opts...)
+	i, _ := b.derive(newOptions(opts))
+
+	return fmt.Sprintf("%s %s (%s)", i.Executable, i.Version, i.Commit)
+}
+
+// Fprint writes the same string that Details would return to w, as with the package-level Fprint function.
+func (b *Build) Fprint(w io.Writer, opts ...Option) (int, error) {
+	o := b.detailsOptions(opts)
+	i, _ := b.derive(o)
+	format, args := o.formatArgs(o.colorize(i, w))
+
+	n, err := fmt.Fprintf(w, format, args...)
+	if err != nil {
+		return n, fmt.Errorf("writing version details: %w", err)
+	}
+
+	return n, nil
+}
+
+// JSON returns the JSON encoding of the Info describing the build, as with the package-level JSON function.
+func (b *Build) JSON(opts ...Option) ([]byte, error) {
+	return marshalJSON(b.Info(opts...), b.options(opts))
+}
+
+// detailsOptions is like options, but for the sentence form of Details and Fprint, where the commit is abbreviated to
+// ShortCommitLength characters unless the Config or the options given ask otherwise.
+func (b *Build) detailsOptions(opts []Option) options {
+	all := make([]Option, 0, 1+len(b.opts)+len(opts))
+	all = append(all, WithShortCommit(ShortCommitLength))
+	all = append(all, b.opts...)
+	all = append(all, opts...)
+
+	return newOptions(all)
+}
+
+// options combines the options from the Config with those given.
+func (b *Build) options(opts []Option) options {
+	all := make([]Option, 0, len(b.opts)+len(opts))
+	all = append(all, b.opts...)
+	all = append(all, opts...)
+
+	return newOptions(all)
+}
+
+// derive returns the Info for the build with the given options applied, along with any lookup errors.
+// The fallbacks are derived once for the options from the Config and then shared by every call whose options derive
+// them the same way, such as those that only abbreviate the commit or redact a field, so that a hot path calling Short
+// or Details repeats none of the work. Any other options derive the fallbacks afresh, although the runtime lookups
+// behind them are still cached.
+func (b *Build) derive(o options) (Info, []error) {
+	o = b.withHost(o)
+
+	key, ok := o.derivationKey()
+	if defaultKey, _ := b.withHost(newOptions(b.opts)).derivationKey(); !ok || key != defaultKey {
+		return deriveWithErrors(b.seed(), o)
+	}
+
+	d := b.derivation()
+	d.once.Do(func() {
+		d.info, d.errs = deriveFallbacks(b.seed(), o)
+	})
+
+	return o.apply(d.info), append([]error(nil), d.errs...)
+}
+
+// withHost marks the options as being for the host binary or not, and applies any prefix from SetEnvPrefix to those
+// for the shared Build.
+func (b *Build) withHost(o options) options {
+	o.host = b.host
+
+	if b == std {
+		o = o.withEnvDefault()
+	}
+
+	return o
+}
+
+// derivation returns the cached derivation for the current generation of the explicit values, replacing any left over
+// from an earlier generation.
+func (b *Build) derivation() *derivation {
+	current := atomic.LoadUint64(&generation)
+
+	b.derivedMu.Lock()
+	defer b.derivedMu.Unlock()
+
+	if b.derived == nil || b.derived.generation != current {
+		b.derived = &derivation{generation: current}
+	}
+
+	return b.derived
+}
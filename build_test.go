@@ -0,0 +1,197 @@
+package version_test
+
+import (
+	"runtime/debug"
+	"testing"
+
+	"go.jlucktay.dev/version"
+)
+
+//nolint:paralleltest // Overrides the package seams.
+func TestNewWithPartialConfig(t *testing.T) {
+	stubRuntime(t)
+
+	build := version.New(version.Config{
+		Executable: "mylib",
+		Version:    "v0.4.0",
+		Commit:     "89abcdef0123",
+	})
+
+	got := build.Info()
+
+	for _, check := range []struct{ name, got, want string }{
+		{"Executable", got.Executable, "mylib"},
+		{"Version", got.Version, "v0.4.0"},
+		{"Commit", got.Commit, "89abcdef0123"},
+		{"BuiltBy", got.BuiltBy, testUser},
+		{"BuiltWith", got.BuiltWith, testGoVersion},
+		{"BuildDate", got.BuildDate, testBuildDate},
+	} {
+		if check.got != check.want {
+			t.Errorf("Info().%s = %q, want %q", check.name, check.got, check.want)
+		}
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestNewFallbackBehaviours(t *testing.T) {
+	stubRuntime(t)
+
+	build := version.New(version.Config{
+		Executable:  "mylib",
+		Version:     "v0.4.0",
+		BuiltBy:     "libdev",
+		Commit:      "89abcdef0123",
+		BuildDate:   "2020-02-20T22:20:20+02:00",
+		DateLayout:  "2006-01-02 15:04",
+		ShortCommit: 4,
+		UTC:         true,
+	})
+
+	want := "mylib v0.4.0 built by libdev from commit 89ab with go1.20.2 at 2020-02-20 20:20."
+
+	if got := build.Details(); got != want {
+		t.Errorf("Details() = %q, want %q", got, want)
+	}
+
+	if got, want := build.Short(), "mylib v0.4.0 (89ab)"; got != want {
+		t.Errorf("Short() = %q, want %q", got, want)
+	}
+
+	if got := build.Info(version.WithShortCommit(6)).Commit; got != "89abcd" {
+		t.Errorf("Info(WithShortCommit(6)).Commit = %q, want the option to win over the Config", got)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestNewIsolated(t *testing.T) {
+	stubRuntime(t)
+
+	testCases := map[string]struct {
+		isolated bool
+		want     version.Info
+	}{
+		"borrows from the host by default": {
+			want: version.Info{
+				Executable: testExecutable,
+				Version:    testVersion,
+				BuiltBy:    testUser,
+				Commit:     testRevision,
+				BuiltWith:  testGoVersion,
+				BuildDate:  testBuildDate,
+			},
+		},
+		"isolated": {
+			isolated: true,
+			want: version.Info{
+				Executable: "unknown",
+				Version:    "v0.0.0-unknown",
+				BuiltBy:    "unknown",
+				Commit:     "unknown",
+				BuiltWith:  testGoVersion,
+				BuildDate:  testBuildDate,
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(name, func(t *testing.T) {
+			got := version.New(version.Config{Isolated: testCase.isolated}).Info()
+
+			got.OS, got.Arch, got.RuntimeGoVersion, got.CGOEnabled = "", "", "", ""
+			if got != testCase.want {
+				t.Errorf("Info() = %#v, want %#v", got, testCase.want)
+			}
+		})
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestNewIsolatedSkipsCGOLookup(t *testing.T) {
+	stubRuntime(t)
+
+	if got := version.New(version.Config{}).Info().CGOEnabled; got != "true" {
+		t.Errorf("Info().CGOEnabled = %q, want the setting of the host binary by default", got)
+	}
+
+	version.Stub(t, version.ReadBuildInfo, func() (*debug.BuildInfo, bool) {
+		t.Error("debug.ReadBuildInfo was called")
+
+		return nil, false
+	})
+
+	build := version.New(version.Config{
+		Executable: "mylib",
+		Version:    "v0.4.0",
+		BuiltBy:    "libdev",
+		Commit:     "89abcde",
+		BuiltWith:  "go1.20.2",
+		BuildDate:  testBuildDate,
+		Isolated:   true,
+	})
+
+	if got := build.Info().CGOEnabled; got != "" {
+		t.Errorf("Info().CGOEnabled = %q, want it left empty for an isolated Build", got)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestNewJSON(t *testing.T) {
+	stubRuntime(t)
+
+	got, err := version.New(version.Config{Executable: "mylib", Version: "v0.4.0"}).JSON(
+		version.WithOmit(version.FieldOS, version.FieldArch, version.FieldRuntimeGoVersion))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"executable":"mylib","version":"v0.4.0","builtBy":"tester","commit":"` + testRevision + `",` +
+		`"builtWith":"go1.20.2","buildDate":"2006-01-02T15:04:05Z","cgoEnabled":"true"}`
+
+	if string(got) != want {
+		t.Errorf("JSON() = %s, want %s", got, want)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestDefaultBacksPackageFunctions(t *testing.T) {
+	stubRuntime(t)
+	version.SetVersion("v5.0.0")
+
+	if version.Default() != version.Default() {
+		t.Error("Default() returned different Builds")
+	}
+
+	if got, want := version.Default().Details(), version.Details(); got != want {
+		t.Errorf("Default().Details() = %q, want %q", got, want)
+	}
+
+	if got, want := version.Default().Info(), version.Current(); got != want {
+		t.Errorf("Default().Info() = %#v, want %#v", got, want)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestBuildsAreIndependent(t *testing.T) {
+	stubRuntime(t)
+
+	first := version.New(version.Config{Executable: "first", Version: "v1.0.0"})
+	second := version.New(version.Config{Executable: "second", Version: "v2.0.0", ShortCommit: 3, Commit: "abcdef"})
+
+	version.SetVersion("v9.9.9")
+	version.SetCommit("fedcba")
+
+	if got := first.Info(); got.Executable != "first" || got.Version != "v1.0.0" || got.Commit != testRevision {
+		t.Errorf("first.Info() = %#v, want its own values", got)
+	}
+
+	if got := second.Info(); got.Executable != "second" || got.Version != "v2.0.0" || got.Commit != "abc" {
+		t.Errorf("second.Info() = %#v, want its own values", got)
+	}
+
+	if got := version.Current(); got.Version != "v9.9.9" || got.Commit != "fedcba" {
+		t.Errorf("Current() = %#v, want the values given to the setters", got)
+	}
+}
@@ -0,0 +1,154 @@
+package version
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrNoBuildInfo is returned when build info is not available in the currently executing binary.
+	ErrNoBuildInfo = errors.New("build info not available")
+
+	// ErrSettingNotRecorded is returned when the Go toolchain did not record a particular build setting.
+	ErrSettingNotRecorded = errors.New("build setting not recorded")
+)
+
+// The keys of the build settings recorded by the Go toolchain that this package reads.
+const (
+	settingCGOEnabled = "CGO_ENABLED"
+	settingRevision   = "vcs.revision"
+	settingModified   = "vcs.modified"
+)
+
+// IsDirty reports whether the binary was built from a working tree with uncommitted modifications, according to the
+// 'vcs.modified' build setting. It returns false if build info is unavailable or the setting was not recorded.
+func IsDirty() bool {
+	value, err := buildSetting(settingModified)
+
+	return err == nil && strings.EqualFold(value, "true")
+}
+
+// CGOEnabled reports whether the binary was built with cgo enabled, according to the 'CGO_ENABLED' build setting.
+// An error is returned if build info is unavailable or the setting was not recorded.
+func CGOEnabled() (bool, error) {
+	value, err := buildSetting(settingCGOEnabled)
+	if err != nil {
+		return false, err
+	}
+
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("parsing %s build setting: %w", settingCGOEnabled, err)
+	}
+
+	return enabled, nil
+}
+
+// buildSetting looks up the value recorded against the given key in the build settings.
+func buildSetting(key string) (string, error) {
+	buildInfo, ok := runtimeLookups().readBuildInfo()
+	if !ok || buildInfo == nil {
+		return "", ErrNoBuildInfo
+	}
+
+	for index := range buildInfo.Settings {
+		if strings.EqualFold(buildInfo.Settings[index].Key, key) {
+			return buildInfo.Settings[index].Value, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: %s", ErrSettingNotRecorded, key)
+}
+
+// BuildSettings returns every build setting recorded by the Go toolchain, such as the 'vcs.*' keys and the flags that
+// were passed to 'go build', verbatim. An empty map is returned if build info is unavailable.
+func BuildSettings() map[string]string {
+	settings := make(map[string]string)
+
+	buildInfo, ok := runtimeLookups().readBuildInfo()
+	if !ok || buildInfo == nil {
+		return settings
+	}
+
+	for index := range buildInfo.Settings {
+		settings[buildInfo.Settings[index].Key] = buildInfo.Settings[index].Value
+	}
+
+	return settings
+}
+
+// ModulePath returns the path of the main module of the currently executing binary, as recorded in build info, or
+// 'unknown' if build info is unavailable.
+func ModulePath() string {
+	buildInfo, ok := runtimeLookups().readBuildInfo()
+	if !ok || buildInfo == nil || buildInfo.Main.Path == "" {
+		return unknownValue
+	}
+
+	return buildInfo.Main.Path
+}
+
+// Module describes a module that was compiled into the currently executing binary.
+type Module struct {
+	Path    string `json:"path"`
+	Version string `json:"version"`
+	Sum     string `json:"sum,omitempty"`
+
+	// Replace is the module that this one was replaced by with a replace directive, if any.
+	Replace *Module `json:"replace,omitempty"`
+}
+
+// String returns the module path and version, followed by the checksum if known, and then any replacement after an
+// arrow, as in 'example.com/foo v1.2.3 h1:abc= => example.com/bar v1.2.4'.
+func (m Module) String() string {
+	s := m.Path
+
+	if m.Version != "" {
+		s += " " + m.Version
+	}
+
+	if m.Sum != "" {
+		s += " " + m.Sum
+	}
+
+	if m.Replace != nil {
+		s += " => " + m.Replace.String()
+	}
+
+	return s
+}
+
+// Dependencies returns the modules that were compiled into the currently executing binary as dependencies of the main
+// module, along with any replacements. An empty slice is returned if build info is unavailable.
+func Dependencies() []Module {
+	buildInfo, ok := runtimeLookups().readBuildInfo()
+	if !ok || buildInfo == nil {
+		return []Module{}
+	}
+
+	deps := make([]Module, 0, len(buildInfo.Deps))
+
+	for _, dep := range buildInfo.Deps {
+		if dep != nil {
+			deps = append(deps, *newModule(dep))
+		}
+	}
+
+	return deps
+}
+
+func newModule(m *debug.Module) *Module {
+	if m == nil {
+		return nil
+	}
+
+	return &Module{
+		Path:    m.Path,
+		Version: m.Version,
+		Sum:     m.Sum,
+		Replace: newModule(m.Replace),
+	}
+}
@@ -0,0 +1,264 @@
+package version_test
+
+import (
+	"errors"
+	"runtime/debug"
+	"testing"
+
+	"go.jlucktay.dev/version"
+)
+
+// withSetting returns a modifier for stubBuildInfo that records the given value against the key, or drops the key if
+// the value is empty.
+func withSetting(key, value string) func(*debug.BuildInfo) {
+	return func(bi *debug.BuildInfo) {
+		settings := make([]debug.BuildSetting, 0, len(bi.Settings))
+
+		for _, setting := range bi.Settings {
+			if setting.Key != key {
+				settings = append(settings, setting)
+			}
+		}
+
+		if value != "" {
+			settings = append(settings, debug.BuildSetting{Key: key, Value: value})
+		}
+
+		bi.Settings = settings
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestIsDirty(t *testing.T) {
+	for value, want := range map[string]bool{
+		"true":  true,
+		"TRUE":  true,
+		"false": false,
+		"":      false,
+	} {
+		value, want := value, want
+
+		t.Run(value, func(t *testing.T) {
+			stubBuildInfo(t, withSetting("vcs.modified", value))
+
+			if got := version.IsDirty(); got != want {
+				t.Errorf("IsDirty() = %t, want %t", got, want)
+			}
+		})
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestIsDirtyWithoutBuildInfo(t *testing.T) {
+	version.Stub(t, version.ReadBuildInfo, func() (*debug.BuildInfo, bool) {
+		return nil, false
+	})
+
+	if version.IsDirty() {
+		t.Error("IsDirty() = true without build info, want false")
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestCGOEnabled(t *testing.T) {
+	for value, want := range map[string]string{
+		"1":     "true",
+		"0":     "false",
+		"true":  "true",
+		"false": "false",
+	} {
+		value, want := value, want
+
+		t.Run(value, func(t *testing.T) {
+			stubRuntime(t)
+			stubBuildInfo(t, withSetting("CGO_ENABLED", value))
+
+			enabled, err := version.CGOEnabled()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := version.Current().CGOEnabled; got != want || (want == "true") != enabled {
+				t.Errorf("CGOEnabled() = %t and Current().CGOEnabled = %q, want %s", enabled, got, want)
+			}
+		})
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestCGOEnabledErrors(t *testing.T) {
+	stubRuntime(t)
+	stubBuildInfo(t, withSetting("CGO_ENABLED", ""))
+
+	if _, err := version.CGOEnabled(); !errors.Is(err, version.ErrSettingNotRecorded) {
+		t.Errorf("CGOEnabled() error = %v, want %v", err, version.ErrSettingNotRecorded)
+	}
+
+	if got := version.Current().CGOEnabled; got != "" {
+		t.Errorf("Current().CGOEnabled = %q, want it left empty", got)
+	}
+
+	stubBuildInfo(t, withSetting("CGO_ENABLED", "maybe"))
+
+	if _, err := version.CGOEnabled(); err == nil {
+		t.Error("CGOEnabled() with a malformed setting returned no error")
+	}
+
+	version.Stub(t, version.ReadBuildInfo, func() (*debug.BuildInfo, bool) {
+		return nil, false
+	})
+
+	if _, err := version.CGOEnabled(); !errors.Is(err, version.ErrNoBuildInfo) {
+		t.Errorf("CGOEnabled() error = %v, want %v", err, version.ErrNoBuildInfo)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestBuildSettings(t *testing.T) {
+	stubRuntime(t)
+
+	got := version.BuildSettings()
+	want := map[string]string{
+		"-compiler":    "gc",
+		"CGO_ENABLED":  "1",
+		"GOOS":         "linux",
+		"vcs":          "git",
+		"vcs.revision": testRevision,
+		"vcs.modified": "false",
+	}
+
+	if len(got) != len(want) {
+		t.Errorf("BuildSettings() = %v, want %v", got, want)
+	}
+
+	for key, value := range want {
+		if got[key] != value {
+			t.Errorf("BuildSettings()[%q] = %q, want %q", key, got[key], value)
+		}
+	}
+
+	got["vcs"] = "hg"
+
+	if again := version.BuildSettings(); again["vcs"] != "git" {
+		t.Error("BuildSettings() returned a map shared between calls")
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestBuildSettingsWithoutBuildInfo(t *testing.T) {
+	version.Stub(t, version.ReadBuildInfo, func() (*debug.BuildInfo, bool) {
+		return nil, false
+	})
+
+	if got := version.BuildSettings(); got == nil || len(got) != 0 {
+		t.Errorf("BuildSettings() = %#v, want an empty map", got)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestDependencies(t *testing.T) {
+	stubRuntime(t)
+	stubBuildInfo(t, func(bi *debug.BuildInfo) {
+		bi.Deps = append(bi.Deps, nil)
+	})
+
+	got := version.Dependencies()
+	want := []version.Module{
+		{Path: "example.com/dep", Version: "v0.1.0", Sum: "h1:dep="},
+		{
+			Path:    "example.com/old",
+			Version: "v1.0.0",
+			Replace: &version.Module{Path: "example.com/new", Version: "v1.0.1"},
+		},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("Dependencies() = %v, want %v", got, want)
+	}
+
+	for index := range want {
+		if got[index].String() != want[index].String() {
+			t.Errorf("Dependencies()[%d] = %v, want %v", index, got[index], want[index])
+		}
+	}
+}
+
+func TestModuleString(t *testing.T) {
+	t.Parallel()
+
+	module := version.Module{
+		Path:    "example.com/foo",
+		Version: "v1.2.3",
+		Sum:     "h1:abc=",
+		Replace: &version.Module{Path: "example.com/bar", Version: "v1.2.4"},
+	}
+
+	if got, want := module.String(), "example.com/foo v1.2.3 h1:abc= => example.com/bar v1.2.4"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	local := version.Module{Path: "example.com/foo", Version: "v1.2.3", Replace: &version.Module{Path: "../foo"}}
+
+	if got, want := local.String(), "example.com/foo v1.2.3 => ../foo"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestDependenciesWithoutBuildInfo(t *testing.T) {
+	version.Stub(t, version.ReadBuildInfo, func() (*debug.BuildInfo, bool) {
+		return nil, false
+	})
+
+	if got := version.Dependencies(); got == nil || len(got) != 0 {
+		t.Errorf("Dependencies() = %#v, want an empty slice", got)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestModulePath(t *testing.T) {
+	stubRuntime(t)
+
+	if got := version.ModulePath(); got != testModulePath {
+		t.Errorf("ModulePath() = %q, want %q", got, testModulePath)
+	}
+
+	if got := version.Current(version.WithModulePath()).ModulePath; got != testModulePath {
+		t.Errorf("Current(WithModulePath()).ModulePath = %q, want %q", got, testModulePath)
+	}
+
+	if got := version.Current().ModulePath; got != "" {
+		t.Errorf("Current().ModulePath = %q, want it left out by default", got)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestModulePathFallsBack(t *testing.T) {
+	testCases := map[string]func() (*debug.BuildInfo, bool){
+		"no build info":  func() (*debug.BuildInfo, bool) { return nil, false },
+		"nil build info": func() (*debug.BuildInfo, bool) { return nil, true },
+		"no main path": func() (*debug.BuildInfo, bool) {
+			bi := testBuildInfo()
+			bi.Main.Path = ""
+
+			return bi, true
+		},
+	}
+
+	for name, readBuildInfo := range testCases {
+		readBuildInfo := readBuildInfo
+
+		t.Run(name, func(t *testing.T) {
+			stubRuntime(t)
+			version.Stub(t, version.ReadBuildInfo, readBuildInfo)
+
+			if got := version.ModulePath(); got != "unknown" {
+				t.Errorf("ModulePath() = %q, want %q", got, "unknown")
+			}
+
+			if got := version.Current(version.WithModulePath()).ModulePath; got != "unknown" {
+				t.Errorf("Current(WithModulePath()).ModulePath = %q, want %q", got, "unknown")
+			}
+		})
+	}
+}
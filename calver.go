@@ -0,0 +1,90 @@
+package version
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidCalVer is returned when a string cannot be parsed as a calendar version.
+	ErrInvalidCalVer = errors.New("invalid calendar version")
+
+	// ErrMixedSchemes is returned when comparing a semantic version with a calendar version.
+	ErrMixedSchemes = errors.New("cannot compare semantic and calendar versions")
+)
+
+// Bounds on the components of a calendar version.
+const (
+	calVerParts    = 3
+	calVerYearLen  = 4
+	calVerMonthLen = 2
+	monthsInYear   = 12
+)
+
+// ParseCalVer parses the given string as a calendar version of the form 'YYYY.MM.N', such as '2024.03.1', returning
+// the year, month, and sequence number in that order. A leading 'v' or 'V' is tolerated, as is a month without a
+// leading zero.
+func ParseCalVer(s string) (int, int, int, error) {
+	parts := strings.Split(trimVPrefix(s), ".")
+	if len(parts) != calVerParts {
+		return 0, 0, 0, fmt.Errorf("%w %q: want %d dot-separated numbers, got %d",
+			ErrInvalidCalVer, s, calVerParts, len(parts))
+	}
+
+	year, ok := parseCalVerPart(parts[0], calVerYearLen, calVerYearLen)
+	if !ok {
+		return 0, 0, 0, fmt.Errorf("%w %q: malformed year %q", ErrInvalidCalVer, s, parts[0])
+	}
+
+	month, ok := parseCalVerPart(parts[1], 1, calVerMonthLen)
+	if !ok || month < 1 || month > monthsInYear {
+		return 0, 0, 0, fmt.Errorf("%w %q: malformed month %q", ErrInvalidCalVer, s, parts[1])
+	}
+
+	sequence, ok := parseCalVerPart(parts[2], 1, len(parts[2]))
+	if !ok {
+		return 0, 0, 0, fmt.Errorf("%w %q: malformed sequence %q", ErrInvalidCalVer, s, parts[2])
+	}
+
+	return year, month, sequence, nil
+}
+
+// compareCalVer compares two calendar versions, or returns an error if either cannot be parsed.
+func compareCalVer(a, b string) (int, error) {
+	yearA, monthA, sequenceA, err := ParseCalVer(a)
+	if err != nil {
+		return 0, err
+	}
+
+	yearB, monthB, sequenceB, err := ParseCalVer(b)
+	if err != nil {
+		return 0, err
+	}
+
+	if c := compareInts(yearA, yearB); c != 0 {
+		return c, nil
+	}
+
+	if c := compareInts(monthA, monthB); c != 0 {
+		return c, nil
+	}
+
+	return compareInts(sequenceA, sequenceB), nil
+}
+
+// parseCalVerPart parses a string of between minLen and maxLen decimal digits, and reports whether it was able to do
+// so.
+func parseCalVerPart(s string, minLen, maxLen int) (int, bool) {
+	if len(s) < minLen || len(s) > maxLen || !isNumeric(s) {
+		return 0, false
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, false
+	}
+
+	return n, true
+}
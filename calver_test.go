@@ -0,0 +1,113 @@
+package version_test
+
+import (
+	"errors"
+	"testing"
+
+	"go.jlucktay.dev/version"
+)
+
+func TestParseCalVer(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		year, month, sequence int
+	}{
+		"2024.03.1":   {2024, 3, 1},
+		"v2024.12.0":  {2024, 12, 0},
+		"V1999.01.42": {1999, 1, 42},
+		"2024.3.1":    {2024, 3, 1},
+	}
+
+	for input, testCase := range testCases {
+		input, testCase := input, testCase
+
+		t.Run(input, func(t *testing.T) {
+			t.Parallel()
+
+			year, month, sequence, err := version.ParseCalVer(input)
+			if err != nil {
+				t.Fatalf("ParseCalVer(%q) error = %v", input, err)
+			}
+
+			if year != testCase.year || month != testCase.month || sequence != testCase.sequence {
+				t.Errorf("ParseCalVer(%q) = %d, %d, %d, want %d, %d, %d",
+					input, year, month, sequence, testCase.year, testCase.month, testCase.sequence)
+			}
+		})
+	}
+}
+
+func TestParseCalVerRejectsMalformedVersions(t *testing.T) {
+	t.Parallel()
+
+	for _, input := range []string{
+		"",
+		"2024.03",
+		"2024.03.1.2",
+		"24.03.1",
+		"20245.03.1",
+		"2024.00.1",
+		"2024.13.1",
+		"2024.003.1",
+		"2024.03.",
+		"2024.03.x",
+		"2024.-3.1",
+	} {
+		input := input
+
+		t.Run(input, func(t *testing.T) {
+			t.Parallel()
+
+			if _, _, _, err := version.ParseCalVer(input); !errors.Is(err, version.ErrInvalidCalVer) {
+				t.Errorf("ParseCalVer(%q) error = %v, want %v", input, err, version.ErrInvalidCalVer)
+			}
+		})
+	}
+}
+
+func TestCompareCalVer(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		a, b string
+		want int
+	}{
+		"equal":              {"2024.03.1", "v2024.3.1", 0},
+		"older year":         {"2023.12.9", "2024.01.0", -1},
+		"newer month":        {"2024.10.0", "2024.09.5", 1},
+		"sequence as number": {"2024.03.10", "2024.03.9", 1},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := version.Compare(testCase.a, testCase.b)
+			if err != nil {
+				t.Fatalf("Compare(%q, %q) error = %v", testCase.a, testCase.b, err)
+			}
+
+			if got != testCase.want {
+				t.Errorf("Compare(%q, %q) = %d, want %d", testCase.a, testCase.b, got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestCompareRejectsMixedSchemes(t *testing.T) {
+	t.Parallel()
+
+	for _, pair := range [][2]string{
+		{"2024.03.1", "v1.2.3"},
+		{"v1.2.3", "2024.03.1"},
+		{"2024.3.1", "v1.2.3"},
+		{"v1.2.3", "2024.3.1"},
+	} {
+		if _, err := version.Compare(pair[0], pair[1]); !errors.Is(err, version.ErrMixedSchemes) {
+			t.Errorf("Compare(%q, %q) error = %v, want %v", pair[0], pair[1], err, version.ErrMixedSchemes)
+		}
+	}
+}
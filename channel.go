@@ -0,0 +1,42 @@
+package version
+
+import "strings"
+
+// The stability channels returned by Channel.
+const (
+	ChannelStable = "stable"
+	ChannelRC     = "rc"
+	ChannelBeta   = "beta"
+	ChannelAlpha  = "alpha"
+	ChannelDev    = "dev"
+)
+
+// Channel returns the stability channel of the currently executing binary, derived from the prerelease component of
+// its version as follows:
+//   - ChannelStable when there is no prerelease, as in 'v1.0.0'.
+//   - ChannelRC, ChannelBeta, or ChannelAlpha when the first prerelease identifier starts with 'rc', 'beta', or 'alpha'
+//     respectively, regardless of case, as in 'v1.0.0-rc.1', 'v1.0.0-beta2', or 'v1.0.0-ALPHA'.
+//   - ChannelDev for any other prerelease, for the default version of 'v0.0.0-unknown', and for a version that cannot
+//     be parsed as a semantic version.
+//
+// Build metadata plays no part in the channel.
+func Channel() string {
+	sv, err := CurrentSemVer()
+	if err != nil {
+		return ChannelDev
+	}
+
+	if sv.Prerelease == "" {
+		return ChannelStable
+	}
+
+	first, _, _ := strings.Cut(strings.ToLower(sv.Prerelease), ".")
+
+	for _, channel := range []string{ChannelRC, ChannelBeta, ChannelAlpha} {
+		if strings.HasPrefix(first, channel) {
+			return channel
+		}
+	}
+
+	return ChannelDev
+}
@@ -0,0 +1,38 @@
+package version_test
+
+import (
+	"testing"
+
+	"go.jlucktay.dev/version"
+)
+
+//nolint:paralleltest // Overrides the package seams.
+func TestChannel(t *testing.T) {
+	testCases := map[string]string{
+		"v1.0.0":            version.ChannelStable,
+		"v1.0.0+build.5":    version.ChannelStable,
+		"v1.0.0-rc.1":       version.ChannelRC,
+		"v1.0.0-RC2":        version.ChannelRC,
+		"v1.0.0-beta2":      version.ChannelBeta,
+		"v1.0.0-beta.1+exp": version.ChannelBeta,
+		"v1.0.0-ALPHA":      version.ChannelAlpha,
+		"v1.0.0-alpha.beta": version.ChannelAlpha,
+		"v1.0.0-snapshot":   version.ChannelDev,
+		"v1.0.0-1.rc":       version.ChannelDev,
+		"v0.0.0-unknown":    version.ChannelDev,
+		"not-a-version":     version.ChannelDev,
+	}
+
+	for stamped, want := range testCases {
+		stamped, want := stamped, want
+
+		t.Run(stamped, func(t *testing.T) {
+			stubRuntime(t)
+			version.SetVersion(stamped)
+
+			if got := version.Channel(); got != want {
+				t.Errorf("Channel() of %q = %q, want %q", stamped, got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+// Package cobraversion provides a ready-made 'version' subcommand for command line tools built with
+// github.com/spf13/cobra.
+package cobraversion
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"go.jlucktay.dev/version"
+)
+
+// ErrUnknownOutput is returned when the '--output' flag is given a value that has no renderer.
+var ErrUnknownOutput = errors.New("unknown output format")
+
+// The number of spaces that each level of nesting is indented by with the '--pretty' flag.
+const prettyIndent = "  "
+
+// The values of the flags other than '--output' that a renderer may take into account.
+type flags struct {
+	pretty bool
+}
+
+// The renderers selectable with the '--output' flag, keyed by flag value.
+//
+//nolint:gochecknoglobals // Treated as a constant lookup table.
+var renderers = map[string]func(flags) (string, error){
+	"text": func(flags) (string, error) {
+		return version.Details(), nil
+	},
+	"json": func(f flags) (string, error) {
+		if f.pretty {
+			b, err := version.JSONIndent("", prettyIndent)
+
+			return string(b), err
+		}
+
+		b, err := version.JSON()
+
+		return string(b), err
+	},
+	"short": func(flags) (string, error) {
+		return version.Short(), nil
+	},
+	"version": func(flags) (string, error) {
+		return version.VersionOnly(), nil
+	},
+	"env": func(flags) (string, error) {
+		return strings.TrimSuffix(version.EnvLines(), "\n"), nil
+	},
+}
+
+// Command returns a 'version' subcommand that prints the version details of the currently executing binary to the
+// command's configured output writer. The '--output' flag selects between the 'text' form from 'version.Details', the
+// 'json' form from 'version.JSON', the 'short' form from 'version.Short', the bare 'version' from
+// 'version.VersionOnly', and the 'env' form from 'version.EnvLines'. The '--pretty' flag indents the 'json' form with
+// 'version.JSONIndent'.
+func Command() *cobra.Command {
+	var (
+		output string
+		f      flags
+	)
+
+	cmd := &cobra.Command{
+		Use:   "version",
+		Short: "Print version information",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			render, ok := renderers[output]
+			if !ok {
+				return fmt.Errorf("%w %q, want one of: %s", ErrUnknownOutput, output, outputNames())
+			}
+
+			s, err := render(f)
+			if err != nil {
+				return fmt.Errorf("rendering version: %w", err)
+			}
+
+			if _, err = fmt.Fprintln(cmd.OutOrStdout(), s); err != nil {
+				return fmt.Errorf("writing version: %w", err)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "text", "output format, one of: "+outputNames())
+	cmd.Flags().BoolVar(&f.pretty, "pretty", false, "indent the json output format")
+
+	return cmd
+}
+
+// outputNames lists the valid values for the '--output' flag in a stable order.
+func outputNames() string {
+	names := make([]string, 0, len(renderers))
+
+	for name := range renderers {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,96 @@
+package cobraversion_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"go.jlucktay.dev/version"
+	"go.jlucktay.dev/version/cobraversion"
+)
+
+func TestCommandOutputs(t *testing.T) {
+	t.Parallel()
+
+	indented, err := version.JSONIndent("", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	compact, err := version.JSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := map[string]struct {
+		args []string
+		want string
+	}{
+		"default":        {nil, version.Details()},
+		"text":           {[]string{"--output", "text"}, version.Details()},
+		"json":           {[]string{"-o", "json"}, string(compact)},
+		"pretty json":    {[]string{"-o", "json", "--pretty"}, string(indented)},
+		"short":          {[]string{"-o", "short"}, version.Short()},
+		"version":        {[]string{"-o", "version"}, version.VersionOnly()},
+		"env":            {[]string{"-o", "env"}, strings.TrimSuffix(version.EnvLines(), "\n")},
+		"pretty ignored": {[]string{"-o", "short", "--pretty"}, version.Short()},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var out bytes.Buffer
+
+			cmd := cobraversion.Command()
+			cmd.SetOut(&out)
+			cmd.SetArgs(testCase.args)
+
+			if err := cmd.Execute(); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := out.String(); got != testCase.want+"\n" {
+				t.Errorf("output = %q, want %q", got, testCase.want+"\n")
+			}
+		})
+	}
+}
+
+func TestCommandRejectsUnknownOutput(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+
+	cmd := cobraversion.Command()
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs([]string{"-o", "xml"})
+
+	err := cmd.Execute()
+	if !errors.Is(err, cobraversion.ErrUnknownOutput) {
+		t.Fatalf("Execute() error = %v, want %v", err, cobraversion.ErrUnknownOutput)
+	}
+
+	if want := "env, json, short, text, version"; !strings.Contains(err.Error(), want) {
+		t.Errorf("Execute() error = %q, want it to list %q", err, want)
+	}
+}
+
+func TestCommandRejectsArguments(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+
+	cmd := cobraversion.Command()
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs([]string{"extra"})
+
+	if err := cmd.Execute(); err == nil {
+		t.Error("Execute() with an argument returned no error")
+	}
+}
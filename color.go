@@ -0,0 +1,100 @@
+package version
+
+import (
+	"io"
+	"os"
+)
+
+// The ANSI escape codes used to highlight values in colored output.
+const (
+	ansiCyan   = "\x1b[36m"
+	ansiYellow = "\x1b[33m"
+	ansiReset  = "\x1b[0m"
+)
+
+// Reports whether the given writer is a terminal.
+// This can be overridden, so that terminal detection is predictable.
+//
+//nolint:gochecknoglobals // Seam for deterministic testing.
+var isTerminal = func(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+
+	return fi.Mode()&os.ModeCharDevice != 0
+}
+
+// colorMode decides whether output should be colored.
+type colorMode int
+
+const (
+	// Never use color. This is the default.
+	colorNever colorMode = iota
+
+	// Use color if the output is a terminal and the 'NO_COLOR' environment variable is not set.
+	colorAuto
+
+	// Always use color, regardless of the output or the environment.
+	colorAlways
+)
+
+// WithColor highlights the version and commit with ANSI color codes in the output of Details and Fprint.
+// Even when enabled, color is only used if the output is a terminal and the 'NO_COLOR' environment variable is not
+// set, so that output piped to a file stays free of escape codes. Details checks standard output, while Fprint checks
+// the writer it was given. Passing false turns color off again, as for a '--no-color' flag.
+func WithColor(enabled bool) Option {
+	return func(o *options) {
+		if enabled {
+			o.color = colorAuto
+		} else {
+			o.color = colorNever
+		}
+	}
+}
+
+// WithForceColor highlights the version and commit as for WithColor, but without checking whether the output is a
+// terminal or whether the 'NO_COLOR' environment variable is set, as for a '--color=always' flag.
+func WithForceColor() Option {
+	return func(o *options) {
+		o.color = colorAlways
+	}
+}
+
+// DetailsColored returns the string from Details, with the version and commit highlighted as for WithColor.
+func DetailsColored(opts ...Option) string {
+	return Details(append(opts, WithColor(true))...)
+}
+
+// shouldColor decides whether output to the given writer should be colored, so that every colored renderer behaves
+// consistently. An explicit WithForceColor or WithColor(false) always wins; otherwise WithColor(true) colors output to
+// a terminal, unless the 'NO_COLOR' environment variable is set.
+func (o options) shouldColor(w io.Writer) bool {
+	switch o.color {
+	case colorAlways:
+		return true
+	case colorAuto:
+		return os.Getenv("NO_COLOR") == "" && isTerminal(w)
+	case colorNever:
+		return false
+	default:
+		return false
+	}
+}
+
+// colorize highlights the version and commit of the given Info, if output to the writer should be colored.
+func (o options) colorize(i Info, w io.Writer) Info {
+	if !o.shouldColor(w) {
+		return i
+	}
+
+	i.Version = ansiCyan + i.Version + ansiReset
+	i.Commit = ansiYellow + i.Commit + ansiReset
+
+	return i
+}
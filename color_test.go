@@ -0,0 +1,147 @@
+package version_test
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"go.jlucktay.dev/version"
+)
+
+const (
+	ansiCyan   = "\x1b[36m"
+	ansiYellow = "\x1b[33m"
+	ansiReset  = "\x1b[0m"
+)
+
+//nolint:paralleltest // Overrides the package seams.
+func TestFprintWithForceColor(t *testing.T) {
+	stubRuntime(t)
+
+	var buf bytes.Buffer
+	if _, err := version.Fprint(&buf, version.WithForceColor()); err != nil {
+		t.Fatal(err)
+	}
+
+	got := buf.String()
+
+	for _, want := range []string{
+		ansiCyan + testVersion + ansiReset,
+		ansiYellow + testRevision[:version.ShortCommitLength] + ansiReset,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Fprint(WithForceColor()) = %q, want it to contain %q", got, want)
+		}
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestColorIsOffByDefault(t *testing.T) {
+	stubRuntime(t)
+	version.Stub(t, version.IsTerminal, func(io.Writer) bool { return true })
+
+	if got := version.Details(); strings.Contains(got, "\x1b[") {
+		t.Errorf("Details() = %q, want no ANSI escape codes", got)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestDetailsColored(t *testing.T) {
+	stubRuntime(t)
+	version.Stub(t, version.IsTerminal, func(io.Writer) bool { return true })
+
+	got := version.DetailsColored()
+
+	if !strings.Contains(got, ansiCyan+testVersion+ansiReset) {
+		t.Errorf("DetailsColored() = %q, want the version highlighted", got)
+	}
+
+	// Without the escape codes, the string is the same as from Details.
+	plain := strings.NewReplacer(ansiCyan, "", ansiYellow, "", ansiReset, "").Replace(got)
+	if want := version.Details(); plain != want {
+		t.Errorf("DetailsColored() without escape codes = %q, want %q", plain, want)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestWithColorFalseOverridesForceColor(t *testing.T) {
+	stubRuntime(t)
+
+	got := version.Details(version.WithForceColor(), version.WithColor(false))
+	if strings.Contains(got, "\x1b[") {
+		t.Errorf("Details(WithForceColor(), WithColor(false)) = %q, want no ANSI escape codes", got)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams and the environment.
+func TestWithColorRespectsTerminalAndNoColor(t *testing.T) {
+	stubRuntime(t)
+
+	testCases := map[string]struct {
+		terminal bool
+		noColor  string
+		want     bool
+	}{
+		"terminal":               {terminal: true, want: true},
+		"not a terminal":         {terminal: false, want: false},
+		"terminal with NO_COLOR": {terminal: true, noColor: "1", want: false},
+		"NO_COLOR of any value":  {terminal: true, noColor: "false", want: false},
+		"neither, with NO_COLOR": {terminal: false, noColor: "1", want: false},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(name, func(t *testing.T) {
+			version.Stub(t, version.IsTerminal, func(io.Writer) bool { return testCase.terminal })
+			t.Setenv("NO_COLOR", testCase.noColor)
+
+			var buf bytes.Buffer
+			if _, err := version.Fprint(&buf, version.WithColor(true)); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := strings.Contains(buf.String(), "\x1b["); got != testCase.want {
+				t.Errorf("Fprint(WithColor(true)) = %q, colored %t, want %t", buf.String(), got, testCase.want)
+			}
+		})
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams and the environment.
+func TestWithForceColorIgnoresTerminalAndNoColor(t *testing.T) {
+	stubRuntime(t)
+	version.Stub(t, version.IsTerminal, func(io.Writer) bool { return false })
+	t.Setenv("NO_COLOR", "1")
+
+	if got := version.Details(version.WithForceColor()); !strings.Contains(got, "\x1b[") {
+		t.Errorf("Details(WithForceColor()) = %q, want ANSI escape codes", got)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams and the environment.
+func TestFprintChecksItsOwnWriter(t *testing.T) {
+	stubRuntime(t)
+	t.Setenv("NO_COLOR", "")
+
+	var terminal, file bytes.Buffer
+
+	version.Stub(t, version.IsTerminal, func(w io.Writer) bool { return w == &terminal })
+
+	if _, err := version.Fprint(&terminal, version.WithColor(true)); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := version.Fprint(&file, version.WithColor(true)); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(terminal.String(), "\x1b[") {
+		t.Errorf("Fprint() to a terminal = %q, want ANSI escape codes", terminal.String())
+	}
+
+	if strings.Contains(file.String(), "\x1b[") {
+		t.Errorf("Fprint() to a file = %q, want no ANSI escape codes", file.String())
+	}
+}
@@ -0,0 +1,61 @@
+package version
+
+import (
+	"runtime/debug"
+	"strings"
+)
+
+// Component is a single entry in the list returned by Components.
+type Component struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+
+	// PURL is a package URL identifying the component, in the form 'pkg:golang/<name>@<version>', or empty if the
+	// component has no version to identify it by, as for a module replaced by a local directory.
+	PURL string `json:"purl,omitempty"`
+}
+
+// Components returns a minimal, SPDX-style list of the components compiled into the currently executing binary, for
+// lightweight supply-chain reporting. The main module comes first, with the version returned by Version, followed by
+// each dependency in the order recorded in build info.
+//
+// A dependency that was replaced with a replace directive is listed under the path and version of its replacement,
+// since that is what was compiled in. An empty slice is returned if build info is unavailable.
+func Components() []Component {
+	buildInfo, ok := runtimeLookups().readBuildInfo()
+	if !ok || buildInfo == nil {
+		return []Component{}
+	}
+
+	components := make([]Component, 0, len(buildInfo.Deps)+1)
+	components = append(components, newComponent(buildInfo.Main.Path, Version()))
+
+	for _, dep := range buildInfo.Deps {
+		if dep == nil {
+			continue
+		}
+
+		components = append(components, componentOf(dep))
+	}
+
+	return components
+}
+
+// componentOf returns the Component for the given module, or for its replacement if it has one.
+func componentOf(m *debug.Module) Component {
+	if m.Replace != nil {
+		m = m.Replace
+	}
+
+	return newComponent(m.Path, m.Version)
+}
+
+func newComponent(name, version string) Component {
+	c := Component{Name: name, Version: version}
+
+	if version != "" && !strings.HasPrefix(name, ".") && !strings.HasPrefix(name, "/") {
+		c.PURL = "pkg:golang/" + name + "@" + version
+	}
+
+	return c
+}
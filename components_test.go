@@ -0,0 +1,64 @@
+package version_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"runtime/debug"
+	"testing"
+
+	"go.jlucktay.dev/version"
+)
+
+//nolint:paralleltest // Overrides the package seams.
+func TestComponents(t *testing.T) {
+	stubRuntime(t)
+	stubBuildInfo(t, func(bi *debug.BuildInfo) {
+		bi.Deps = append(bi.Deps, nil, &debug.Module{
+			Path:    "example.com/local",
+			Version: "v0.0.0",
+			Replace: &debug.Module{Path: "../local"},
+		})
+	})
+
+	want := []version.Component{
+		{Name: testModulePath, Version: testVersion, PURL: "pkg:golang/example.com/testapp@v1.2.3"},
+		{Name: "example.com/dep", Version: "v0.1.0", PURL: "pkg:golang/example.com/dep@v0.1.0"},
+		{Name: "example.com/new", Version: "v1.0.1", PURL: "pkg:golang/example.com/new@v1.0.1"},
+		{Name: "../local"},
+	}
+
+	if got := version.Components(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Components() =\n%#v\nwant\n%#v", got, want)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestComponentsJSON(t *testing.T) {
+	stubRuntime(t)
+	stubBuildInfo(t, func(bi *debug.BuildInfo) {
+		bi.Deps = []*debug.Module{{Path: "./vendored"}}
+	})
+
+	got, err := json.Marshal(version.Components())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `[{"name":"example.com/testapp","version":"v1.2.3","purl":"pkg:golang/example.com/testapp@v1.2.3"},` +
+		`{"name":"./vendored","version":""}]`
+	if string(got) != want {
+		t.Errorf("json.Marshal(Components()) =\n%s\nwant\n%s", got, want)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestComponentsWithoutBuildInfo(t *testing.T) {
+	stubRuntime(t)
+	version.Stub(t, version.ReadBuildInfo, func() (*debug.BuildInfo, bool) {
+		return nil, false
+	})
+
+	if got := version.Components(); got == nil || len(got) != 0 {
+		t.Errorf("Components() = %#v, want an empty slice", got)
+	}
+}
@@ -0,0 +1,110 @@
+package version
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ErrInvalidConstraint is returned when a version constraint cannot be parsed.
+var ErrInvalidConstraint = errors.New("invalid version constraint")
+
+// The comparison operators that may prefix each version in a constraint.
+// Two-character operators are listed first, so that they are matched in preference to their one-character prefixes.
+//
+//nolint:gochecknoglobals // Treated as a constant lookup table.
+var constraintOperators = []string{">=", "<=", "!=", ">", "<", "="}
+
+// A single comparison within a constraint, such as '>=1.2.0'.
+type constraintTerm struct {
+	operator string
+	version  SemVer
+}
+
+// Satisfies reports whether the version of the currently executing binary satisfies the given constraint.
+//
+// A constraint is made up of one or more terms separated by commas and/or whitespace, all of which must hold for the
+// constraint to be satisfied, such as '>=1.2.0 <2.0.0'. Each term is a semantic version optionally prefixed by one of
+// the operators '=', '!=', '>', '>=', '<', or '<=', with a missing operator meaning '='.
+// An error is returned if the constraint or the current version cannot be parsed.
+func Satisfies(constraint string) (bool, error) {
+	terms, err := parseConstraint(constraint)
+	if err != nil {
+		return false, err
+	}
+
+	current, err := CurrentSemVer()
+	if err != nil {
+		return false, err
+	}
+
+	for _, term := range terms {
+		if !term.matches(current) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func parseConstraint(constraint string) ([]constraintTerm, error) {
+	tokens := strings.FieldsFunc(constraint, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("%w %q: no terms", ErrInvalidConstraint, constraint)
+	}
+
+	terms := make([]constraintTerm, 0, len(tokens))
+
+	for index := 0; index < len(tokens); index++ {
+		operator, rawVersion := splitOperator(tokens[index])
+
+		// Allow for whitespace between an operator and its version, as in '>= 1.2.0'.
+		if rawVersion == "" && index+1 < len(tokens) {
+			index++
+			rawVersion = tokens[index]
+		}
+
+		sv, err := ParseSemVer(rawVersion)
+		if err != nil {
+			return nil, wrapSentinel(ErrInvalidConstraint, fmt.Sprintf(" %q", constraint), err)
+		}
+
+		terms = append(terms, constraintTerm{operator: operator, version: sv})
+	}
+
+	return terms, nil
+}
+
+// splitOperator separates any leading comparison operator from the version in a constraint token.
+func splitOperator(token string) (string, string) {
+	for _, operator := range constraintOperators {
+		if strings.HasPrefix(token, operator) {
+			return operator, token[len(operator):]
+		}
+	}
+
+	return "=", token
+}
+
+func (ct constraintTerm) matches(sv SemVer) bool {
+	cmp := sv.Compare(ct.version)
+
+	switch ct.operator {
+	case "!=":
+		return cmp != 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	default:
+		return cmp == 0
+	}
+}
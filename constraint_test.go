@@ -0,0 +1,67 @@
+package version_test
+
+import (
+	"errors"
+	"testing"
+
+	"go.jlucktay.dev/version"
+)
+
+//nolint:paralleltest // Overrides the package seams.
+func TestSatisfies(t *testing.T) {
+	stubRuntime(t)
+
+	testCases := map[string]bool{
+		"1.2.3":              true,
+		"=v1.2.3":            true,
+		"!=1.2.3":            false,
+		">=1.2.0":            true,
+		">= 1.2.0":           true,
+		">1.2.3":             false,
+		"<2.0.0":             true,
+		"<=1.2.3":            true,
+		">=1.2.0 <2.0.0":     true,
+		">=1.2.0, <1.2.3":    false,
+		">1.2.3-rc.1,<1.3.0": true,
+	}
+
+	for constraint, want := range testCases {
+		got, err := version.Satisfies(constraint)
+		if err != nil {
+			t.Errorf("Satisfies(%q) error = %v", constraint, err)
+
+			continue
+		}
+
+		if got != want {
+			t.Errorf("Satisfies(%q) = %t, want %t", constraint, got, want)
+		}
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestSatisfiesRejectsMalformedConstraints(t *testing.T) {
+	stubRuntime(t)
+
+	for _, constraint := range []string{"", " , ", ">=", ">=1.2", "~1.2.3", ">=2.0.0 || <1.0.0"} {
+		_, err := version.Satisfies(constraint)
+		if !errors.Is(err, version.ErrInvalidConstraint) {
+			t.Errorf("Satisfies(%q) error = %v, want %v", constraint, err, version.ErrInvalidConstraint)
+		}
+	}
+
+	// The error from parsing the version within the constraint is kept in the chain.
+	if _, err := version.Satisfies(">=1.2"); !errors.Is(err, version.ErrInvalidSemVer) {
+		t.Errorf("Satisfies() error = %v, want it to wrap %v", err, version.ErrInvalidSemVer)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestSatisfiesRejectsMalformedCurrentVersion(t *testing.T) {
+	stubRuntime(t)
+	version.SetVersion("nightly")
+
+	if _, err := version.Satisfies(">=1.0.0"); !errors.Is(err, version.ErrInvalidSemVer) {
+		t.Errorf("Satisfies() error = %v, want %v", err, version.ErrInvalidSemVer)
+	}
+}
@@ -0,0 +1,38 @@
+package version
+
+import (
+	"context"
+	"fmt"
+)
+
+// DetailsContext returns the same string as Details, but gives up looking up the user who built the binary if ctx is
+// done first, falling back to 'unknown' for builtBy. This keeps a lookup that blocks for seconds, as can happen on a
+// host whose user database is served over the network, from stalling startup.
+//
+// If ctx is done by the time the string is ready, its error is returned alongside the string, which is still usable.
+// A lookup that was abandoned carries on in the background, so that later calls can use its result.
+func DetailsContext(ctx context.Context, opts ...Option) (string, error) {
+	return std.DetailsContext(ctx, opts...)
+}
+
+// DetailsContext returns a string describing the build, as with the package-level DetailsContext function.
+func (b *Build) DetailsContext(ctx context.Context, opts ...Option) (string, error) {
+	all := make([]Option, 0, len(opts)+1)
+	all = append(all, opts...)
+	all = append(all, withUserLookupDone(ctx.Done()))
+
+	details := b.Details(all...)
+
+	if err := ctx.Err(); err != nil {
+		return details, fmt.Errorf("looking up version details: %w", err)
+	}
+
+	return details, nil
+}
+
+// withUserLookupDone abandons the lookup of the current user when the given channel is closed.
+func withUserLookupDone(done <-chan struct{}) Option {
+	return func(o *options) {
+		o.userLookupDone = done
+	}
+}
@@ -0,0 +1,87 @@
+package version_test
+
+import (
+	"context"
+	"errors"
+	"os/user"
+	"strings"
+	"testing"
+	"time"
+
+	"go.jlucktay.dev/version"
+)
+
+//nolint:paralleltest // Overrides the package seams.
+func TestDetailsContext(t *testing.T) {
+	stubRuntime(t)
+
+	got, err := version.DetailsContext(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := version.Details(); got != want {
+		t.Errorf("DetailsContext() = %q, want %q", got, want)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestDetailsContextCancelled(t *testing.T) {
+	stubRuntime(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	got, err := version.DetailsContext(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("DetailsContext() error = %v, want %v", err, context.Canceled)
+	}
+
+	if !strings.Contains(got, " built by unknown ") {
+		t.Errorf("DetailsContext() = %q, want the builder to fall back to unknown", got)
+	}
+
+	// The fallback is not cached, so a later call without a deadline finds the user.
+	if got := version.Details(); !strings.Contains(got, " built by "+testUser+" ") {
+		t.Errorf("Details() = %q, want the builder %q", got, testUser)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestDetailsContextAbandonsSlowLookup(t *testing.T) {
+	stubRuntime(t)
+
+	release := make(chan struct{})
+	version.Stub(t, version.UserCurrent, func() (*user.User, error) {
+		<-release
+
+		return &user.User{Username: testUser}, nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	got, err := version.DetailsContext(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("DetailsContext() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	if !strings.Contains(got, " built by unknown ") {
+		t.Errorf("DetailsContext() = %q, want the builder to fall back to unknown", got)
+	}
+
+	// The lookup carries on in the background, and its result is used once it returns.
+	close(release)
+
+	if got := version.Details(); !strings.Contains(got, " built by "+testUser+" ") {
+		t.Errorf("Details() after the lookup returned = %q, want the builder %q", got, testUser)
+	}
+
+	// With the result cached, even a context that is already done gets it.
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+
+	if got, _ := version.DetailsContext(ctx); !strings.Contains(got, " built by "+testUser+" ") {
+		t.Errorf("DetailsContext() after the lookup returned = %q, want the builder %q", got, testUser)
+	}
+}
@@ -0,0 +1,46 @@
+package version
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+)
+
+// CSVHeader returns the column names for CSVRecord, which are the JSON keys documented on the Info type, in the same
+// order. Columns for the optional fields, such as 'cgoEnabled', are always present, so that rows from different
+// binaries line up.
+func CSVHeader() []string {
+	fields := Info{}.allFields()
+	header := make([]string, 0, len(fields))
+
+	for _, field := range fields {
+		header = append(header, field.Name)
+	}
+
+	return header
+}
+
+// CSVRecord returns the values of the Info in the column order given by CSVHeader.
+// The values are not escaped, so they should be written with a 'csv.Writer'.
+func (i Info) CSVRecord() []string {
+	fields := i.allFields()
+	record := make([]string, 0, len(fields))
+
+	for _, field := range fields {
+		record = append(record, field.Value)
+	}
+
+	return record
+}
+
+// CSV writes the header from CSVHeader followed by the record of the Info describing the caller to w, with any values
+// containing commas, quotes, or newlines quoted as required.
+func CSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.WriteAll([][]string{CSVHeader(), Current().CSVRecord()}); err != nil {
+		return fmt.Errorf("writing version info as CSV: %w", err)
+	}
+
+	return nil
+}
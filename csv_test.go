@@ -0,0 +1,101 @@
+package version_test
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go.jlucktay.dev/version"
+)
+
+func TestCSVHeaderMatchesJSONKeys(t *testing.T) {
+	t.Parallel()
+
+	want := []string{
+		version.FieldExecutable,
+		version.FieldVersion,
+		version.FieldBuiltBy,
+		version.FieldCommit,
+		version.FieldBuiltWith,
+		version.FieldBuildDate,
+		version.FieldOS,
+		version.FieldArch,
+		version.FieldRuntimeGoVersion,
+		version.FieldCGOEnabled,
+		version.FieldExecutablePath,
+		version.FieldHostname,
+		version.FieldModulePath,
+	}
+
+	if got := version.CSVHeader(); !reflect.DeepEqual(got, want) {
+		t.Errorf("CSVHeader() = %q, want %q", got, want)
+	}
+
+	// The columns line up with the keys of an Info encoded as JSON with every field set.
+	encoded, err := json.Marshal(version.Info{CGOEnabled: "true", ExecutablePath: "x", Hostname: "x", ModulePath: "x"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(string(encoded)))
+	if _, err := decoder.Token(); err != nil {
+		t.Fatal(err)
+	}
+
+	for index := 0; decoder.More(); index++ {
+		key, err := decoder.Token()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if key != want[index] {
+			t.Errorf("JSON key %d = %v, want %q", index, key, want[index])
+		}
+
+		if _, err := decoder.Token(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestCSVRecord(t *testing.T) {
+	t.Parallel()
+
+	got := version.Info{Executable: "myapp", Version: "v1.2.3", Hostname: "buildhost"}.CSVRecord()
+	want := []string{"myapp", "v1.2.3", "", "", "", "", "", "", "", "", "", "buildhost", ""}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CSVRecord() = %q, want %q", got, want)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestCSVQuotesValues(t *testing.T) {
+	stubRuntime(t)
+	version.SetBuiltBy(`Doe, Jane "JD"`)
+
+	var sb strings.Builder
+	if err := version.CSV(&sb); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(sb.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("CSV() wrote %d lines, want 2:\n%s", len(lines), sb.String())
+	}
+
+	if want := `testapp,v1.2.3,"Doe, Jane ""JD""",`; !strings.HasPrefix(lines[1], want) {
+		t.Errorf("CSV() record = %q, want it to start with %q", lines[1], want)
+	}
+
+	records, err := csv.NewReader(strings.NewReader(sb.String())).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := [][]string{version.CSVHeader(), version.Current().CSVRecord()}; !reflect.DeepEqual(records, want) {
+		t.Errorf("CSV() read back as %q, want %q", records, want)
+	}
+}
@@ -0,0 +1,87 @@
+package version
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrUnknownBuildDate is returned when the build date could not be derived.
+var ErrUnknownBuildDate = errors.New("unknown build date")
+
+// BuildTime parses the build timestamp of the currently executing binary, which is expected to be in RFC3339 form.
+// The zero time is returned along with an error if the build date is unknown or cannot be parsed.
+func BuildTime() (time.Time, error) {
+	date := BuildDate()
+
+	if date == unknownValue {
+		return time.Time{}, ErrUnknownBuildDate
+	}
+
+	t, err := time.Parse(time.RFC3339, date)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parsing build date %q: %w", date, err)
+	}
+
+	return t, nil
+}
+
+// BuildAge returns the time elapsed since the currently executing binary was built.
+// An error is returned if the build date is unknown or cannot be parsed.
+func BuildAge() (time.Duration, error) {
+	t, err := BuildTime()
+	if err != nil {
+		return 0, err
+	}
+
+	return now().Sub(t), nil
+}
+
+// BuildAgeHuman describes the time elapsed since the currently executing binary was built in human terms, such as
+// '3 days ago' or '2 hours ago', rounding down to the largest whole unit of days, hours, or minutes.
+// Anything under a minute is 'just now', and a build date in the future, as from clock skew, is described as such, as
+// in 'in 5 minutes'. If the build date is unknown or cannot be parsed, 'unknown' is returned.
+func BuildAgeHuman() string {
+	age, err := BuildAge()
+	if err != nil {
+		return unknownValue
+	}
+
+	return humanizeAge(age)
+}
+
+func humanizeAge(age time.Duration) string {
+	const day = 24 * time.Hour
+
+	future := age < 0
+	if future {
+		age = -age
+	}
+
+	var (
+		count int64
+		unit  string
+	)
+
+	switch {
+	case age < time.Minute:
+		return "just now"
+	case age < time.Hour:
+		count, unit = int64(age/time.Minute), "minute"
+	case age < day:
+		count, unit = int64(age/time.Hour), "hour"
+	default:
+		count, unit = int64(age/day), "day"
+	}
+
+	phrase := fmt.Sprintf("%d %s", count, unit)
+	if count != 1 {
+		phrase += "s"
+	}
+
+	if future {
+		return "in " + phrase
+	}
+
+	return phrase + " ago"
+}
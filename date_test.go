@@ -0,0 +1,150 @@
+package version_test
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+	"time"
+
+	"go.jlucktay.dev/version"
+)
+
+//nolint:paralleltest // Overrides the package seams.
+func TestBuildTime(t *testing.T) {
+	stubRuntime(t)
+
+	got, err := version.BuildTime()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !got.Equal(testModTime()) {
+		t.Errorf("BuildTime() = %v, want %v", got, testModTime())
+	}
+
+	version.SetBuildDate("2020-02-20T20:20:20+02:00")
+
+	got, err = version.BuildTime()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := time.Date(2020, time.February, 20, 18, 20, 20, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("BuildTime() = %v, want %v", got, want)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestBuildTimeErrors(t *testing.T) {
+	stubRuntime(t)
+
+	version.SetBuildDate("unknown")
+
+	if got, err := version.BuildTime(); !errors.Is(err, version.ErrUnknownBuildDate) || !got.IsZero() {
+		t.Errorf("BuildTime() = %v, %v, want the zero time and %v", got, err, version.ErrUnknownBuildDate)
+	}
+
+	version.SetBuildDate("yesterday")
+
+	if got, err := version.BuildTime(); err == nil || !got.IsZero() {
+		t.Errorf("BuildTime() = %v, %v, want the zero time and an error", got, err)
+	}
+
+	if _, err := version.BuildAge(); err == nil {
+		t.Error("BuildAge() with a malformed build date returned no error")
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestBuildAge(t *testing.T) {
+	stubRuntime(t)
+
+	age, err := version.BuildAge()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if age <= 0 {
+		t.Errorf("BuildAge() = %v, want a build date in the past", age)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestBuildAgeUsesClock(t *testing.T) {
+	stubRuntime(t)
+	version.Stub(t, version.Now, func() time.Time {
+		return testModTime().Add(36 * time.Hour)
+	})
+
+	age, err := version.BuildAge()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := 36 * time.Hour; age != want {
+		t.Errorf("BuildAge() = %v, want %v", age, want)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestBuildDateFromStat(t *testing.T) {
+	stubRuntime(t)
+
+	var statted []string
+
+	version.Stub(t, version.OSStat, func(name string) (fs.FileInfo, error) {
+		statted = append(statted, name)
+
+		return nil, fs.ErrPermission
+	})
+
+	if got := version.BuildDate(); got != "unknown" {
+		t.Errorf("BuildDate() with a failing stat = %q, want %q", got, "unknown")
+	}
+
+	if len(statted) != 1 || statted[0] != testExePath {
+		t.Errorf("stat called with %q, want just %q", statted, testExePath)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestBuildAgeHuman(t *testing.T) {
+	testCases := map[time.Duration]string{
+		0:                          "just now",
+		59 * time.Second:           "just now",
+		time.Minute:                "1 minute ago",
+		59 * time.Minute:           "59 minutes ago",
+		time.Hour:                  "1 hour ago",
+		23*time.Hour + time.Minute: "23 hours ago",
+		24 * time.Hour:             "1 day ago",
+		3*24*time.Hour + time.Hour: "3 days ago",
+		-5 * time.Minute:           "in 5 minutes",
+		-30 * time.Second:          "just now",
+		-2 * 24 * time.Hour:        "in 2 days",
+	}
+
+	for age, want := range testCases {
+		age, want := age, want
+
+		t.Run(age.String(), func(t *testing.T) {
+			stubRuntime(t)
+			version.Stub(t, version.Now, func() time.Time {
+				return testModTime().Add(age)
+			})
+
+			if got := version.BuildAgeHuman(); got != want {
+				t.Errorf("BuildAgeHuman() = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestBuildAgeHumanUnknown(t *testing.T) {
+	stubRuntime(t)
+	version.SetBuildDate("whenever")
+
+	if got := version.BuildAgeHuman(); got != "unknown" {
+		t.Errorf("BuildAgeHuman() = %q, want %q", got, "unknown")
+	}
+}
@@ -0,0 +1,59 @@
+package version
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidDescribe is returned by DescribeInfo when the version is neither a plain tag nor of the form produced by
+// 'git describe'.
+var ErrInvalidDescribe = errors.New("invalid git describe version")
+
+// Matches the '<tag>-<ahead>-g<hash>' form produced by 'git describe --tags' for a commit past the nearest tag.
+//
+//nolint:gochecknoglobals // Compiled once, and treated as a constant.
+var describePattern = regexp.MustCompile(`^(.+)-([0-9]+)-g([0-9a-f]{4,})$`)
+
+// DescribeInfo splits a version stamped with 'git describe --tags', such as 'v1.2.3-4-gabc1234', into the nearest tag,
+// the number of commits made since that tag, and the abbreviated hash of the commit that was built, as in 'v1.2.3', 4,
+// and 'abc1234'. Any '-dirty' suffix added by '--dirty' is ignored.
+//
+// A plain tag, as stamped for a build of the tagged commit itself, is returned with an ahead count of zero and an empty
+// hash. An error is returned if the tag is not a semantic or calendar version, so that a prerelease such as
+// 'v1.2.3-rc.1' is not mistaken for a describe suffix.
+func DescribeInfo() (string, int, string, error) {
+	return parseDescribe(Version())
+}
+
+func parseDescribe(s string) (string, int, string, error) {
+	trimmed := strings.TrimSuffix(s, dirtySuffix)
+
+	if match := describePattern.FindStringSubmatch(trimmed); match != nil && isTag(match[1]) {
+		ahead, err := strconv.Atoi(match[2])
+		if err != nil {
+			return "", 0, "", fmt.Errorf("%w %q: malformed commit count %q", ErrInvalidDescribe, s, match[2])
+		}
+
+		return match[1], ahead, match[3], nil
+	}
+
+	if !isTag(trimmed) {
+		return "", 0, "", fmt.Errorf("%w %q: not a tag or a describe string", ErrInvalidDescribe, s)
+	}
+
+	return trimmed, 0, "", nil
+}
+
+// isTag reports whether the given string parses as either a semantic or a calendar version.
+func isTag(s string) bool {
+	if _, err := ParseSemVer(s); err == nil {
+		return true
+	}
+
+	_, _, _, err := ParseCalVer(s)
+
+	return err == nil
+}
@@ -0,0 +1,61 @@
+package version_test
+
+import (
+	"errors"
+	"testing"
+
+	"go.jlucktay.dev/version"
+)
+
+//nolint:paralleltest // Overrides the package seams.
+func TestDescribeInfo(t *testing.T) {
+	testCases := map[string]struct {
+		tag   string
+		ahead int
+		hash  string
+	}{
+		"v1.2.3-4-gabc1234":          {"v1.2.3", 4, "abc1234"},
+		"v1.2.3-4-gabc1234-dirty":    {"v1.2.3", 4, "abc1234"},
+		"v1.2.3-rc.1-12-g0123456789": {"v1.2.3-rc.1", 12, "0123456789"},
+		"2024.03.1-1-gdeadbeef":      {"2024.03.1", 1, "deadbeef"},
+		"v1.2.3":                     {"v1.2.3", 0, ""},
+		"v1.2.3-rc.1":                {"v1.2.3-rc.1", 0, ""},
+		"v1.2.3-dirty":               {"v1.2.3", 0, ""},
+		"v1.2.3-4-gnothex":           {"v1.2.3-4-gnothex", 0, ""},
+	}
+
+	for stamped, testCase := range testCases {
+		stamped, testCase := stamped, testCase
+
+		t.Run(stamped, func(t *testing.T) {
+			stubRuntime(t)
+			version.SetVersion(stamped)
+
+			tag, ahead, hash, err := version.DescribeInfo()
+			if err != nil {
+				t.Fatalf("DescribeInfo() error = %v", err)
+			}
+
+			if tag != testCase.tag || ahead != testCase.ahead || hash != testCase.hash {
+				t.Errorf("DescribeInfo() of %q = %q, %d, %q, want %q, %d, %q",
+					stamped, tag, ahead, hash, testCase.tag, testCase.ahead, testCase.hash)
+			}
+		})
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestDescribeInfoRejectsOtherVersions(t *testing.T) {
+	for _, stamped := range []string{"main", "abc1234", "not-a-tag-4-gabc1234"} {
+		stamped := stamped
+
+		t.Run(stamped, func(t *testing.T) {
+			stubRuntime(t)
+			version.SetVersion(stamped)
+
+			if _, _, _, err := version.DescribeInfo(); !errors.Is(err, version.ErrInvalidDescribe) {
+				t.Errorf("DescribeInfo() of %q error = %v, want %v", stamped, err, version.ErrInvalidDescribe)
+			}
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package version
+
+// Diff returns the fields whose values differ between a and b, keyed by field name as with the Field* constants, with
+// the value from a followed by the value from b. Identical inputs give an empty map.
+func Diff(a, b Info) map[string][2]string {
+	diff := make(map[string][2]string)
+	fieldsB := b.allFields()
+
+	for index, fieldA := range a.allFields() {
+		if fieldA.Value != fieldsB[index].Value {
+			diff[fieldA.Name] = [2]string{fieldA.Value, fieldsB[index].Value}
+		}
+	}
+
+	return diff
+}
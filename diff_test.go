@@ -0,0 +1,39 @@
+package version_test
+
+import (
+	"reflect"
+	"testing"
+
+	"go.jlucktay.dev/version"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	a := version.Info{Executable: "myapp", Version: "v1.2.3", Commit: "abc", BuiltWith: "go1.20.2", OS: "linux"}
+	b := a
+	b.Version = "v1.3.0"
+	b.Commit = "def"
+	b.Hostname = "buildhost"
+
+	want := map[string][2]string{
+		version.FieldVersion:  {"v1.2.3", "v1.3.0"},
+		version.FieldCommit:   {"abc", "def"},
+		version.FieldHostname: {"", "buildhost"},
+	}
+
+	if got := version.Diff(a, b); !reflect.DeepEqual(got, want) {
+		t.Errorf("Diff() = %v, want %v", got, want)
+	}
+}
+
+func TestDiffOfIdenticalInfo(t *testing.T) {
+	t.Parallel()
+
+	info := version.Info{Executable: "myapp", Version: "v1.2.3"}
+
+	got := version.Diff(info, info)
+	if got == nil || len(got) != 0 {
+		t.Errorf("Diff() of identical values = %#v, want an empty map", got)
+	}
+}
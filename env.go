@@ -0,0 +1,88 @@
+package version
+
+import (
+	"os"
+	"sync"
+)
+
+// The names of the environment variables consulted for any fields that were not set with ldflags, once the environment
+// has been opted into with WithEnvPrefix, and before the prefix given to it is applied.
+const (
+	EnvExecutable = "EXECUTABLE"
+	EnvVersion    = "VERSION"
+	EnvBuiltBy    = "BUILT_BY"
+	EnvCommit     = "BUILD_COMMIT"
+	EnvBuiltWith  = "BUILT_WITH"
+	EnvBuildDate  = "BUILD_DATE"
+)
+
+// WithEnvPrefix fills any fields that were not set with ldflags from environment variables, named by the Env*
+// constants with the given prefix prepended, so that 'MYAPP_' will look up 'MYAPP_VERSION'.
+//
+// The environment is not consulted without this option or SetEnvPrefix, as a stray variable such as 'VERSION', which
+// is common in CI and in container base images, would otherwise silently take precedence over the values derived from
+// the runtime. An empty prefix opts into the unprefixed names. Given to a single call, this option takes precedence
+// over any prefix set with SetEnvPrefix.
+func WithEnvPrefix(prefix string) Option {
+	return func(o *options) {
+		o.env = true
+		o.envPrefix = prefix
+	}
+}
+
+// The prefix set with SetEnvPrefix, and whether it has been set at all, as an empty prefix is valid.
+//
+//nolint:gochecknoglobals // Applies to the shared Build, which is itself global.
+var (
+	envMu     sync.RWMutex
+	envSet    bool
+	envPrefix string
+)
+
+// SetEnvPrefix opts the host binary into the environment for every call, as if WithEnvPrefix had been given the same
+// prefix, including the functions that take no options, such as Version, Short, Banner, and MustBeStamped. This suits
+// a container image whose version is injected at deploy time, as with 'MYAPP_VERSION'. Values set with ldflags or the
+// Set* functions still take precedence, and a Build from New is not affected.
+// Like the setters, it is intended to be called once at startup. The environment is read the next time the values are
+// derived, and the result is cached until one of the explicit values changes.
+func SetEnvPrefix(prefix string) {
+	envMu.Lock()
+	envSet, envPrefix = true, prefix
+	envMu.Unlock()
+
+	invalidate()
+}
+
+// withEnvDefault returns the options with the prefix from SetEnvPrefix applied, unless they already have one.
+func (o options) withEnvDefault() options {
+	if o.env {
+		return o
+	}
+
+	envMu.RLock()
+	o.env, o.envPrefix = envSet, envPrefix
+	envMu.RUnlock()
+
+	return o
+}
+
+// fillFromEnv populates any empty fields from their respective environment variables.
+func (i *Info) fillFromEnv(prefix string) {
+	fields := []struct {
+		value *string
+		name  string
+	}{
+		{&i.Executable, EnvExecutable},
+		{&i.Version, EnvVersion},
+		{&i.BuiltBy, EnvBuiltBy},
+		{&i.Commit, EnvCommit},
+		{&i.BuiltWith, EnvBuiltWith},
+		{&i.BuildDate, EnvBuildDate},
+	}
+
+	for _, field := range fields {
+		if *field.value == "" {
+			*field.value = os.Getenv(prefix + field.name)
+		}
+	}
+}
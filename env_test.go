@@ -0,0 +1,142 @@
+package version_test
+
+import (
+	"testing"
+
+	"go.jlucktay.dev/version"
+)
+
+//nolint:paralleltest // Overrides the package seams and the environment.
+func TestEnvironmentIgnoredByDefault(t *testing.T) {
+	stubRuntime(t)
+	t.Setenv(version.EnvVersion, "v6.6.6")
+	t.Setenv(version.EnvCommit, "fromenv")
+
+	if got := version.Current(); got.Version != testVersion || got.Commit != testRevision {
+		t.Errorf("Current() version and commit = %q and %q, want the environment ignored", got.Version, got.Commit)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams and the environment.
+func TestWithEnvPrefix(t *testing.T) {
+	stubRuntime(t)
+
+	t.Setenv("VERSION", "v6.6.6")
+	t.Setenv("MYAPP_EXECUTABLE", "envapp")
+	t.Setenv("MYAPP_VERSION", " v7.7.7\n")
+	t.Setenv("MYAPP_BUILT_BY", "envuser")
+	t.Setenv("MYAPP_BUILD_COMMIT", "envcommit")
+	t.Setenv("MYAPP_BUILT_WITH", "go1.0")
+	t.Setenv("MYAPP_BUILD_DATE", "2001-01-01T00:00:00Z")
+
+	got := version.Current(version.WithEnvPrefix("MYAPP_"))
+	want := version.Info{
+		Executable: "envapp",
+		Version:    "v7.7.7",
+		BuiltBy:    "envuser",
+		Commit:     "envcommit",
+		BuiltWith:  "go1.0",
+		BuildDate:  "2001-01-01T00:00:00Z",
+	}
+
+	got = version.Info{
+		Executable: got.Executable,
+		Version:    got.Version,
+		BuiltBy:    got.BuiltBy,
+		Commit:     got.Commit,
+		BuiltWith:  got.BuiltWith,
+		BuildDate:  got.BuildDate,
+	}
+
+	if got != want {
+		t.Errorf("Current(WithEnvPrefix) =\n%#v\nwant\n%#v", got, want)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams and the environment.
+func TestWithEmptyEnvPrefix(t *testing.T) {
+	stubRuntime(t)
+	t.Setenv(version.EnvVersion, "v6.6.6")
+
+	if got := version.Current(version.WithEnvPrefix("")).Version; got != "v6.6.6" {
+		t.Errorf("Current(WithEnvPrefix(\"\")).Version = %q, want %q", got, "v6.6.6")
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams and the environment.
+func TestEnvironmentBelowLdflags(t *testing.T) {
+	stubRuntime(t)
+	t.Setenv(version.EnvVersion, "v6.6.6")
+	t.Setenv(version.EnvBuiltBy, "")
+	version.SetVersion("v1.0.0")
+
+	got := version.Current(version.WithEnvPrefix(""))
+
+	if got.Version != "v1.0.0" {
+		t.Errorf("Current().Version = %q, want the ldflag to win", got.Version)
+	}
+
+	if got.BuiltBy != testUser {
+		t.Errorf("Current().BuiltBy = %q, want an empty variable to fall through to the runtime", got.BuiltBy)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams and the environment.
+func TestSetEnvPrefix(t *testing.T) {
+	stubRuntime(t)
+	t.Setenv("MYAPP_VERSION", "v7.7.7")
+	t.Setenv("MYAPP_BUILD_COMMIT", "abcdef0123456789")
+
+	version.SetEnvPrefix("MYAPP_")
+
+	if got := version.Version(); got != "v7.7.7" {
+		t.Errorf("Version() = %q, want the value from the environment", got)
+	}
+
+	if got, want := version.Short(), testExecutable+" v7.7.7 (abcdef0)"; got != want {
+		t.Errorf("Short() = %q, want %q", got, want)
+	}
+
+	// A container stamped through the environment passes the stamping checks.
+	if err := version.Validate(); err != nil {
+		t.Errorf("Validate() = %v, want nil", err)
+	}
+
+	// An explicit option on a single call still takes precedence.
+	t.Setenv("OTHER_VERSION", "v8.8.8")
+
+	if got := version.Current(version.WithEnvPrefix("OTHER_")).Version; got != "v8.8.8" {
+		t.Errorf("Current(WithEnvPrefix(\"OTHER_\")).Version = %q, want %q", got, "v8.8.8")
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams and the environment.
+func TestSetEnvPrefixBelowLdflags(t *testing.T) {
+	stubRuntime(t)
+	t.Setenv("MYAPP_VERSION", "v7.7.7")
+	t.Setenv("MYAPP_BUILT_BY", "envuser")
+
+	version.SetEnvPrefix("MYAPP_")
+	version.SetVersion("v1.0.0")
+
+	if got := version.Version(); got != "v1.0.0" {
+		t.Errorf("Version() = %q, want the ldflag to win", got)
+	}
+
+	if got := version.BuiltBy(); got != "envuser" {
+		t.Errorf("BuiltBy() = %q, want the value from the environment", got)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams and the environment.
+func TestSetEnvPrefixLeavesNewBuildsAlone(t *testing.T) {
+	stubRuntime(t)
+	t.Setenv("MYAPP_VERSION", "v7.7.7")
+
+	version.SetEnvPrefix("MYAPP_")
+
+	lib := version.New(version.Config{Executable: "mylib"})
+	if got := lib.Info().Version; got == "v7.7.7" {
+		t.Errorf("New().Info().Version = %q, want the environment left unread", got)
+	}
+}
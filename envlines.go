@@ -0,0 +1,65 @@
+package version
+
+import (
+	"strings"
+	"unicode"
+)
+
+// EnvLines returns the fields of the Info describing the caller as 'KEY=value' lines suitable for sourcing into a
+// shell, such as 'VERSION=v1.2.3' and 'BUILT_BY=jlucktay'. Fields that can be read back from the environment are keyed
+// by their Env* constant, such as 'BUILD_COMMIT', and the rest by the upper snake case form of the field name.
+// Each value is single-quoted so that spaces and other special characters survive intact.
+// The fields and their order are the same as for Fields.
+func EnvLines(opts ...Option) string {
+	var sb strings.Builder
+
+	for _, field := range Fields(opts...) {
+		sb.WriteString(envKey(field.Name))
+		sb.WriteByte('=')
+		sb.WriteString(shellQuote(field.Value))
+		sb.WriteByte('\n')
+	}
+
+	return sb.String()
+}
+
+// envKey returns the environment variable name for the given field, matching those read by WithEnvPrefix.
+func envKey(name string) string {
+	switch name {
+	case FieldExecutable:
+		return EnvExecutable
+	case FieldVersion:
+		return EnvVersion
+	case FieldBuiltBy:
+		return EnvBuiltBy
+	case FieldCommit:
+		return EnvCommit
+	case FieldBuiltWith:
+		return EnvBuiltWith
+	case FieldBuildDate:
+		return EnvBuildDate
+	default:
+		return upperSnakeCase(name)
+	}
+}
+
+// upperSnakeCase converts a camel case name like 'builtBy' into 'BUILT_BY'.
+func upperSnakeCase(name string) string {
+	var sb strings.Builder
+
+	for index, r := range name {
+		if index > 0 && unicode.IsUpper(r) {
+			sb.WriteByte('_')
+		}
+
+		sb.WriteRune(unicode.ToUpper(r))
+	}
+
+	return sb.String()
+}
+
+// shellQuote wraps the value in single quotes, escaping any single quotes within it, so that a POSIX shell will read
+// it back verbatim.
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
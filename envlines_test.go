@@ -0,0 +1,74 @@
+package version_test
+
+import (
+	"strings"
+	"testing"
+
+	"go.jlucktay.dev/version"
+)
+
+//nolint:paralleltest // Overrides the package seams.
+func TestEnvLines(t *testing.T) {
+	stubRuntime(t)
+
+	want := "EXECUTABLE='testapp'\n" +
+		"VERSION='v1.2.3'\n" +
+		"BUILT_BY='tester'\n" +
+		"BUILD_COMMIT='" + testRevision + "'\n" +
+		"BUILT_WITH='go1.20.2'\n" +
+		"BUILD_DATE='2006-01-02T15:04:05Z'\n"
+
+	if got := version.EnvLines(); got != want {
+		t.Errorf("EnvLines() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestEnvLinesQuoting(t *testing.T) {
+	stubRuntime(t)
+	version.SetBuiltBy("o'brien & co")
+
+	if got, want := version.EnvLines(), `BUILT_BY='o'\''brien & co'`+"\n"; !strings.Contains(got, want) {
+		t.Errorf("EnvLines() =\n%s\nwant it to contain %q", got, want)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams and the environment.
+func TestEnvLinesReadBackWithEnvPrefix(t *testing.T) {
+	stubRuntime(t)
+
+	version.SetCommit("abcdef0")
+	version.SetBuiltBy("ci")
+
+	lines := version.EnvLines()
+
+	version.SetCommit("")
+	version.SetBuiltBy("")
+
+	for _, line := range strings.Split(strings.TrimSuffix(lines, "\n"), "\n") {
+		key, value, _ := strings.Cut(line, "=")
+		t.Setenv("APP_"+key, strings.Trim(value, "'"))
+	}
+
+	got := version.Current(version.WithEnvPrefix("APP_"))
+
+	if got.Commit != "abcdef0" || got.BuiltBy != "ci" {
+		t.Errorf("Current() commit and builtBy = %q and %q, want them read back from the environment",
+			got.Commit, got.BuiltBy)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestEnvLinesWithPlatform(t *testing.T) {
+	stubRuntime(t)
+
+	got := version.EnvLines(version.WithPlatform(), version.WithOmit(version.FieldBuiltBy))
+
+	if !strings.Contains(got, "\nOS='") || !strings.Contains(got, "\nARCH='") {
+		t.Errorf("EnvLines(WithPlatform()) =\n%s\nwant OS and ARCH lines", got)
+	}
+
+	if strings.Contains(got, "BUILT_BY=") {
+		t.Errorf("EnvLines(WithOmit(builtBy)) =\n%s\nwant no BUILT_BY line", got)
+	}
+}
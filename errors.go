@@ -0,0 +1,27 @@
+package version
+
+// sentinelError wraps an underlying error together with one of the sentinel errors of this package, so that
+// 'errors.Is' matches either of them. A second '%w' verb in 'fmt.Errorf' would do the same, but only from Go 1.20.
+type sentinelError struct {
+	sentinel error
+	detail   string
+	err      error
+}
+
+// wrapSentinel returns an error reading '<sentinel><detail>: <err>' that matches both the sentinel and err.
+func wrapSentinel(sentinel error, detail string, err error) error {
+	return &sentinelError{sentinel: sentinel, detail: detail, err: err}
+}
+
+func (se *sentinelError) Error() string {
+	return se.sentinel.Error() + se.detail + ": " + se.err.Error()
+}
+
+// Is reports whether the target is the sentinel, leaving 'errors.Is' to match the underlying error through Unwrap.
+func (se *sentinelError) Is(target error) bool {
+	return target == se.sentinel //nolint:errorlint // Sentinels are compared by identity.
+}
+
+func (se *sentinelError) Unwrap() error {
+	return se.err
+}
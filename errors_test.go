@@ -0,0 +1,228 @@
+package version_test
+
+import (
+	"errors"
+	"io/fs"
+	"os/user"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"testing"
+
+	"go.jlucktay.dev/version"
+)
+
+// The errors returned by the failing seams, to be found wrapped in the errors from InfoWithErrors.
+var (
+	errNoExecutable = errors.New("no executable")
+	errNoStat       = errors.New("no stat")
+	errNoUser       = errors.New("no user")
+	errNoHostname   = errors.New("no hostname")
+)
+
+// stubFailingSeam makes the named runtime lookup fail until the end of the test.
+func stubFailingSeam(t *testing.T, seam string) {
+	t.Helper()
+
+	switch seam {
+	case "executable":
+		version.Stub(t, version.OSExecutable, func() (string, error) { return "", errNoExecutable })
+	case "stat":
+		version.Stub(t, version.OSStat, func(string) (fs.FileInfo, error) { return nil, errNoStat })
+	case "user":
+		version.Stub(t, version.UserCurrent, func() (*user.User, error) { return nil, errNoUser })
+		version.Stub(t, version.OSGetuid, func() int { return 1000 })
+	case "build info":
+		version.Stub(t, version.ReadBuildInfo, func() (*debug.BuildInfo, bool) { return nil, false })
+	case "hostname":
+		version.Stub(t, version.OSHostname, func() (string, error) { return "", errNoHostname })
+	default:
+		t.Fatalf("unknown seam %q", seam)
+	}
+}
+
+// uidFallback returns the builtBy value expected when the user lookup fails for the given user ID, which is not used on
+// Windows.
+func uidFallback(uid int) string {
+	if runtime.GOOS == "windows" {
+		return "unknown"
+	}
+
+	return "uid:" + strconv.Itoa(uid)
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestInfoWithErrorsWithoutFailures(t *testing.T) {
+	stubRuntime(t)
+
+	info, errs := version.InfoWithErrors(version.WithHostname())
+	if len(errs) != 0 {
+		t.Errorf("InfoWithErrors() errors = %v, want none", errs)
+	}
+
+	if want := version.Current(version.WithHostname()); info != want {
+		t.Errorf("InfoWithErrors() =\n%#v\nwant the same as Current()\n%#v", info, want)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestInfoWithErrorsReportsEachFailingSeam(t *testing.T) {
+	testCases := map[string]struct {
+		message string
+		check   func(version.Info) bool
+	}{
+		"executable": {
+			message: "looking up executable: no executable",
+			check:   func(i version.Info) bool { return i.Executable == "unknown" && i.BuildDate == "unknown" },
+		},
+		"stat": {
+			message: "looking up executable: no stat",
+			check:   func(i version.Info) bool { return i.Executable == testExecutable && i.BuildDate == "unknown" },
+		},
+		"user": {
+			message: "looking up current user: no user",
+			check:   func(i version.Info) bool { return i.BuiltBy == uidFallback(1000) },
+		},
+		"build info": {
+			message: "build info not available",
+			check:   func(i version.Info) bool { return i.Commit == "unknown" && i.BuiltWith == "unknown" },
+		},
+		"hostname": {
+			message: "looking up hostname: no hostname",
+			check:   func(i version.Info) bool { return i.Hostname == "unknown" },
+		},
+	}
+
+	for seam, testCase := range testCases {
+		seam, testCase := seam, testCase
+
+		t.Run(seam, func(t *testing.T) {
+			stubRuntime(t)
+			stubFailingSeam(t, seam)
+
+			info, errs := version.InfoWithErrors(version.WithHostname())
+
+			if len(errs) != 1 || errs[0].Error() != testCase.message {
+				t.Errorf("InfoWithErrors() errors = %q, want just %q", errs, testCase.message)
+			}
+
+			if !testCase.check(info) {
+				t.Errorf("InfoWithErrors() = %#v, want the fallbacks for a failing %s", info, seam)
+			}
+
+			// The plain functions give the same values without the errors.
+			if plain := version.Current(version.WithHostname()); plain != info {
+				t.Errorf("Current() =\n%#v\nwant the same as InfoWithErrors()\n%#v", plain, info)
+			}
+		})
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestInfoWithErrorsReportsEveryFailure(t *testing.T) {
+	stubRuntime(t)
+
+	for _, seam := range []string{"executable", "user", "build info", "hostname"} {
+		stubFailingSeam(t, seam)
+	}
+
+	_, errs := version.InfoWithErrors(version.WithHostname(), version.WithExecutablePath())
+
+	// The executable is looked up for several fields, but its failure is only reported once.
+	want := []string{
+		"looking up executable: no executable",
+		"build info not available",
+		"looking up current user: no user",
+		"looking up hostname: no hostname",
+	}
+
+	got := make([]string, 0, len(errs))
+	for _, err := range errs {
+		got = append(got, err.Error())
+	}
+
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("InfoWithErrors() errors =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestInfoWithErrorsSkipsLookupsForExplicitValues(t *testing.T) {
+	stubRuntime(t)
+	stubFailingSeam(t, "executable")
+	stubFailingSeam(t, "user")
+
+	version.SetExecutable("myapp")
+	version.SetBuiltBy("ci")
+	version.SetBuildDate(testBuildDate)
+
+	if _, errs := version.InfoWithErrors(); len(errs) != 0 {
+		t.Errorf("InfoWithErrors() errors = %v, want none, as no lookup was needed", errs)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestInfoWithErrorsMatchSentinels(t *testing.T) {
+	testCases := map[string]struct {
+		sentinel, underlying error
+	}{
+		"executable": {version.ErrExecutableLookup, errNoExecutable},
+		"stat":       {version.ErrExecutableLookup, errNoStat},
+		"user":       {version.ErrUserLookup, errNoUser},
+		"build info": {version.ErrNoBuildInfo, version.ErrNoBuildInfo},
+		"hostname":   {version.ErrHostnameLookup, errNoHostname},
+	}
+
+	sentinels := []error{
+		version.ErrExecutableLookup,
+		version.ErrUserLookup,
+		version.ErrNoBuildInfo,
+		version.ErrHostnameLookup,
+	}
+
+	for seam, testCase := range testCases {
+		seam, testCase := seam, testCase
+
+		t.Run(seam, func(t *testing.T) {
+			stubRuntime(t)
+			stubFailingSeam(t, seam)
+
+			_, errs := version.InfoWithErrors(version.WithHostname())
+			if len(errs) != 1 {
+				t.Fatalf("InfoWithErrors() errors = %v, want one", errs)
+			}
+
+			if !errors.Is(errs[0], testCase.sentinel) || !errors.Is(errs[0], testCase.underlying) {
+				t.Errorf("InfoWithErrors() error %q does not match both %q and %q",
+					errs[0], testCase.sentinel, testCase.underlying)
+			}
+
+			// The error matches none of the other sentinels, so that callers can branch on them.
+			for _, other := range sentinels {
+				if other != testCase.sentinel && errors.Is(errs[0], other) {
+					t.Errorf("InfoWithErrors() error %q also matches %q", errs[0], other)
+				}
+			}
+		})
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestUserLookupErrorExposesUnderlyingType(t *testing.T) {
+	stubRuntime(t)
+	version.Stub(t, version.UserCurrent, func() (*user.User, error) {
+		return nil, user.UnknownUserIdError(1000)
+	})
+	version.Stub(t, version.OSGetuid, func() int { return 1000 })
+
+	_, errs := version.InfoWithErrors()
+	if len(errs) != 1 || !errors.Is(errs[0], version.ErrUserLookup) {
+		t.Fatalf("InfoWithErrors() errors = %v, want one matching %v", errs, version.ErrUserLookup)
+	}
+
+	var unknownUser user.UnknownUserIdError
+	if !errors.As(errs[0], &unknownUser) || int(unknownUser) != 1000 {
+		t.Errorf("errors.As(%v) = %d, want the underlying %T", errs[0], unknownUser, unknownUser)
+	}
+}
@@ -0,0 +1,70 @@
+package version
+
+import "testing"
+
+// Pointers to the seams, so that tests in the external test package can replace them with Stub.
+//
+//nolint:gochecknoglobals // Test-only exports of the seams.
+var (
+	Now           = &now
+	OSExecutable  = &osExecutable
+	OSExit        = &osExit
+	OSGetuid      = &osGetuid
+	OSHostname    = &osHostname
+	OSStat        = &osStat
+	ReadBuildInfo = &readBuildInfo
+	RuntimeGOOS   = &runtimeGOOS
+	UserCurrent   = &userCurrent
+	IsTerminal    = &isTerminal
+)
+
+// Stub replaces the given seam with value until the end of the test, resetting the package both before and after, so
+// that no cached lookup made through the original survives into the test, or through the stub out of it.
+func Stub[T any](tb testing.TB, seam *T, value T) {
+	tb.Helper()
+
+	original := *seam
+	*seam = value
+
+	Reset()
+
+	tb.Cleanup(func() {
+		*seam = original
+
+		Reset()
+	})
+}
+
+// Reset returns the package to a pristine state, as if none of the symbols had been set with ldflags or any of the
+// setters, no version had been loaded with LoadVersionFrom, and no prefix had been set with SetEnvPrefix, so that each
+// test can start from a known baseline.
+// The cached results of the runtime lookups are discarded too, so that they are made again through any overridden
+// seams, as is every Build added with Register.
+func Reset() {
+	memoMu.Lock()
+	memo = &lookups{}
+	memoMu.Unlock()
+
+	registryMu.Lock()
+	registry = map[string]*Build{}
+	registryMu.Unlock()
+
+	seedMu.Lock()
+	executable, version, builtBy, commit, builtWith, buildDate = "", "", "", "", "", ""
+	seedMu.Unlock()
+
+	loadedMu.Lock()
+	loadedVersion = ""
+	loadedMu.Unlock()
+
+	envMu.Lock()
+	envSet, envPrefix = false, ""
+	envMu.Unlock()
+
+	invalidate()
+}
+
+// Seed returns the explicit values held in the ldflag symbols and set by the setters, before any fallbacks.
+func Seed() Info {
+	return seed()
+}
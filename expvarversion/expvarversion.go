@@ -0,0 +1,34 @@
+// Package expvarversion publishes the version details of the currently executing binary through the standard library's
+// expvar package.
+//
+// This lives apart from the core version package because importing expvar registers a '/debug/vars' handler on
+// 'http.DefaultServeMux' as a side effect, which not every user of the core package will want.
+package expvarversion
+
+import (
+	"expvar"
+	"sync"
+
+	"go.jlucktay.dev/version"
+)
+
+// Name is the name that the version details are published under.
+const Name = "build"
+
+//nolint:gochecknoglobals // Guards against publishing the same name twice, which would panic.
+var once sync.Once
+
+// Publish registers an 'expvar.Var' named 'build', whose String method yields the JSON form of the Info describing the
+// caller. Calling Publish more than once is safe, as is calling it when another 'build' var has already been
+// published, in which case the existing var is left in place.
+func Publish() {
+	once.Do(func() {
+		if expvar.Get(Name) != nil {
+			return
+		}
+
+		expvar.Publish(Name, expvar.Func(func() any {
+			return version.Current()
+		}))
+	})
+}
@@ -0,0 +1,31 @@
+package expvarversion_test
+
+import (
+	"encoding/json"
+	"expvar"
+	"testing"
+
+	"go.jlucktay.dev/version"
+	"go.jlucktay.dev/version/expvarversion"
+)
+
+func TestPublish(t *testing.T) {
+	t.Parallel()
+
+	expvarversion.Publish()
+	expvarversion.Publish()
+
+	published := expvar.Get(expvarversion.Name)
+	if published == nil {
+		t.Fatalf("no var published as %q", expvarversion.Name)
+	}
+
+	var got version.Info
+	if err := json.Unmarshal([]byte(published.String()), &got); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := version.Current(); got != want {
+		t.Errorf("published %#v, want %#v", got, want)
+	}
+}
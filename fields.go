@@ -0,0 +1,118 @@
+package version
+
+// The names of the fields of an Info, matching their JSON keys.
+const (
+	FieldExecutable       = "executable"
+	FieldVersion          = "version"
+	FieldBuiltBy          = "builtBy"
+	FieldCommit           = "commit"
+	FieldBuiltWith        = "builtWith"
+	FieldBuildDate        = "buildDate"
+	FieldOS               = "os"
+	FieldArch             = "arch"
+	FieldRuntimeGoVersion = "runtimeGoVersion"
+	FieldCGOEnabled       = "cgoEnabled"
+	FieldExecutablePath   = "executablePath"
+	FieldHostname         = "hostname"
+	FieldModulePath       = "modulePath"
+)
+
+// Field is a single named value from an Info.
+type Field struct {
+	Name  string
+	Value string
+}
+
+// Fields returns the fields of the Info describing the caller with all fallbacks applied, in the order executable,
+// version, builtBy, commit, builtWith, buildDate. If the WithPlatform option is given, os and arch follow. Any fields
+// dropped with WithOmit are left out.
+func Fields(opts ...Option) []Field {
+	o := newOptions(opts)
+
+	return o.withoutOmitted(Current(opts...).fields(o))
+}
+
+// Map returns the fields of the Info describing the caller keyed by their JSON names, holding the same keys and values
+// as the output of JSON, for splicing into a larger response such as that of a readiness endpoint. A fresh map is
+// returned on each call, so it is safe to modify.
+func Map(opts ...Option) map[string]string {
+	o := newOptions(opts)
+	fields := o.withoutOmitted(Current(opts...).allFields())
+	m := make(map[string]string, len(fields))
+
+	for _, field := range fields {
+		if field.Value != "" || !omitsEmpty(field.Name) {
+			m[field.Name] = field.Value
+		}
+	}
+
+	return m
+}
+
+// fields returns the fields of the Info in their documented order, including any optional ones selected by the given
+// options.
+func (i Info) fields(o options) []Field {
+	fields := []Field{
+		{FieldExecutable, i.Executable},
+		{FieldVersion, i.Version},
+		{FieldBuiltBy, i.BuiltBy},
+		{FieldCommit, i.Commit},
+		{FieldBuiltWith, i.BuiltWith},
+		{FieldBuildDate, i.BuildDate},
+	}
+
+	if o.platform {
+		fields = append(fields, Field{FieldOS, i.OS}, Field{FieldArch, i.Arch})
+	}
+
+	return fields
+}
+
+// allFields returns every field of the Info in the order they are declared, whether set or not.
+func (i Info) allFields() []Field {
+	return []Field{
+		{FieldExecutable, i.Executable},
+		{FieldVersion, i.Version},
+		{FieldBuiltBy, i.BuiltBy},
+		{FieldCommit, i.Commit},
+		{FieldBuiltWith, i.BuiltWith},
+		{FieldBuildDate, i.BuildDate},
+		{FieldOS, i.OS},
+		{FieldArch, i.Arch},
+		{FieldRuntimeGoVersion, i.RuntimeGoVersion},
+		{FieldCGOEnabled, i.CGOEnabled},
+		{FieldExecutablePath, i.ExecutablePath},
+		{FieldHostname, i.Hostname},
+		{FieldModulePath, i.ModulePath},
+	}
+}
+
+// omitsEmpty reports whether the named field is left out of encodings of an Info when empty, as with the fields of Info
+// tagged 'omitempty'.
+func omitsEmpty(name string) bool {
+	switch name {
+	case FieldCGOEnabled, FieldExecutablePath, FieldHostname, FieldModulePath:
+		return true
+	default:
+		return false
+	}
+}
+
+// fieldRefs returns a pointer to each field of the Info, keyed by name.
+func (i *Info) fieldRefs() map[string]*string {
+	return map[string]*string{
+		FieldExecutable:       &i.Executable,
+		FieldVersion:          &i.Version,
+		FieldBuiltBy:          &i.BuiltBy,
+		FieldCommit:           &i.Commit,
+		FieldBuiltWith:        &i.BuiltWith,
+		FieldBuildDate:        &i.BuildDate,
+		FieldOS:               &i.OS,
+		FieldArch:             &i.Arch,
+		FieldRuntimeGoVersion: &i.RuntimeGoVersion,
+		FieldCGOEnabled:       &i.CGOEnabled,
+		FieldExecutablePath:   &i.ExecutablePath,
+		FieldHostname:         &i.Hostname,
+		FieldModulePath:       &i.ModulePath,
+	}
+}
@@ -0,0 +1,95 @@
+package version_test
+
+import (
+	"reflect"
+	"runtime"
+	"testing"
+
+	"go.jlucktay.dev/version"
+)
+
+// assertFields compares a list of fields, including their order.
+func assertFields(t *testing.T, got, want []version.Field) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("got %d fields %v, want %d fields %v", len(got), got, len(want), want)
+	}
+
+	for index := range want {
+		if got[index] != want[index] {
+			t.Errorf("field %d = %v, want %v", index, got[index], want[index])
+		}
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestFields(t *testing.T) {
+	stubRuntime(t)
+
+	want := []version.Field{
+		{Name: version.FieldExecutable, Value: testExecutable},
+		{Name: version.FieldVersion, Value: testVersion},
+		{Name: version.FieldBuiltBy, Value: testUser},
+		{Name: version.FieldCommit, Value: testRevision},
+		{Name: version.FieldBuiltWith, Value: testGoVersion},
+		{Name: version.FieldBuildDate, Value: testBuildDate},
+	}
+
+	assertFields(t, version.Fields(), want)
+
+	withPlatform := append(want[:len(want):len(want)],
+		version.Field{Name: version.FieldOS, Value: runtime.GOOS},
+		version.Field{Name: version.FieldArch, Value: runtime.GOARCH},
+	)
+
+	assertFields(t, version.Fields(version.WithPlatform()), withPlatform)
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestMap(t *testing.T) {
+	stubRuntime(t)
+
+	got := version.Map(version.WithHostname())
+
+	want := map[string]string{
+		version.FieldExecutable:       testExecutable,
+		version.FieldVersion:          testVersion,
+		version.FieldBuiltBy:          testUser,
+		version.FieldCommit:           testRevision,
+		version.FieldBuiltWith:        testGoVersion,
+		version.FieldBuildDate:        testBuildDate,
+		version.FieldOS:               runtime.GOOS,
+		version.FieldArch:             runtime.GOARCH,
+		version.FieldRuntimeGoVersion: runtime.Version(),
+		version.FieldCGOEnabled:       "true",
+		version.FieldHostname:         testHostname,
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Map() =\n%v\nwant\n%v", got, want)
+	}
+
+	// Each call returns a fresh map, so changing one leaves the next untouched.
+	got[version.FieldVersion] = "changed"
+	delete(got, version.FieldCommit)
+
+	if again := version.Map(version.WithHostname()); !reflect.DeepEqual(again, want) {
+		t.Errorf("Map() after changing an earlier result =\n%v\nwant\n%v", again, want)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestMapWithOmit(t *testing.T) {
+	stubRuntime(t)
+
+	got := version.Map(version.WithOmit(version.FieldBuiltBy))
+
+	if value, ok := got[version.FieldBuiltBy]; ok {
+		t.Errorf("Map(WithOmit(builtBy)) has builtBy = %q, want it absent", value)
+	}
+
+	if _, ok := got[version.FieldHostname]; ok {
+		t.Errorf("Map() has a hostname, want it absent unless asked for")
+	}
+}
@@ -0,0 +1,51 @@
+package version
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+	"sync"
+)
+
+// ErrEmptyVersionFile is returned by LoadVersionFrom when the file holds nothing but whitespace.
+var ErrEmptyVersionFile = errors.New("version file is empty")
+
+// The version most recently loaded with LoadVersionFrom.
+//
+//nolint:gochecknoglobals // Loaded once at startup and read on every derivation.
+var (
+	loadedMu      sync.RWMutex
+	loadedVersion string
+)
+
+// LoadVersionFrom reads a version from the named file in fsys, such as a 'VERSION' file embedded with 'go:embed', with
+// any surrounding whitespace and trailing newline trimmed. The loaded version is used in preference to any environment
+// variable or runtime-derived fallback, but a version set with ldflags still takes precedence.
+func LoadVersionFrom(fsys fs.FS, name string) error {
+	b, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return fmt.Errorf("reading version from %q: %w", name, err)
+	}
+
+	v := strings.TrimSpace(string(b))
+	if v == "" {
+		return fmt.Errorf("%w: %s", ErrEmptyVersionFile, name)
+	}
+
+	loadedMu.Lock()
+	loadedVersion = v
+	loadedMu.Unlock()
+
+	invalidate()
+
+	return nil
+}
+
+// getLoadedVersion returns the version most recently loaded with LoadVersionFrom, if any.
+func getLoadedVersion() string {
+	loadedMu.RLock()
+	defer loadedMu.RUnlock()
+
+	return loadedVersion
+}
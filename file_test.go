@@ -0,0 +1,64 @@
+package version_test
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"go.jlucktay.dev/version"
+)
+
+//nolint:paralleltest // Overrides the package seams.
+func TestLoadVersionFrom(t *testing.T) {
+	stubRuntime(t)
+
+	fsys := fstest.MapFS{"VERSION": {Data: []byte("  v3.1.4\n")}}
+
+	if err := version.LoadVersionFrom(fsys, "VERSION"); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := version.Version(); got != "v3.1.4" {
+		t.Errorf("Version() = %q, want the loaded version %q", got, "v3.1.4")
+	}
+
+	version.SetVersion("v9.0.0")
+
+	if got := version.Version(); got != "v9.0.0" {
+		t.Errorf("Version() = %q, want the ldflag to take precedence", got)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams and the environment.
+func TestLoadVersionFromBeatsEnvironment(t *testing.T) {
+	stubRuntime(t)
+	t.Setenv(version.EnvVersion, "v6.6.6")
+
+	if err := version.LoadVersionFrom(fstest.MapFS{"VERSION": {Data: []byte("v3.1.4")}}, "VERSION"); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := version.Current(version.WithEnvPrefix("")).Version; got != "v3.1.4" {
+		t.Errorf("Version() = %q, want the loaded version to beat the environment", got)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestLoadVersionFromErrors(t *testing.T) {
+	stubRuntime(t)
+
+	fsys := fstest.MapFS{"EMPTY": {Data: []byte(" \n\t")}}
+
+	if err := version.LoadVersionFrom(fsys, "EMPTY"); !errors.Is(err, version.ErrEmptyVersionFile) {
+		t.Errorf("LoadVersionFrom(empty) = %v, want %v", err, version.ErrEmptyVersionFile)
+	}
+
+	if err := version.LoadVersionFrom(fsys, "MISSING"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("LoadVersionFrom(missing) = %v, want %v", err, fs.ErrNotExist)
+	}
+
+	if got := version.Version(); got != testVersion {
+		t.Errorf("Version() after failed loads = %q, want %q", got, testVersion)
+	}
+}
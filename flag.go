@@ -0,0 +1,49 @@
+package version
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// versionFlag is a boolean 'flag.Value' that prints the version details and exits when set.
+type versionFlag struct {
+	w io.Writer
+}
+
+// VersionFlag returns a 'flag.Value' which, when set, writes the string from Details to w and exits with status 0.
+// It behaves as a boolean flag, so no value needs to be given on the command line:
+//
+//	flag.Var(version.VersionFlag(os.Stdout), "version", "print version information and exit")
+func VersionFlag(w io.Writer) flag.Value {
+	return versionFlag{w: w}
+}
+
+// IsBoolFlag lets the flag package know that this flag does not need a value.
+func (versionFlag) IsBoolFlag() bool {
+	return true
+}
+
+func (versionFlag) String() string {
+	return strconv.FormatBool(false)
+}
+
+func (vf versionFlag) Set(s string) error {
+	set, err := strconv.ParseBool(s)
+	if err != nil {
+		return fmt.Errorf("parsing version flag: %w", err)
+	}
+
+	if !set {
+		return nil
+	}
+
+	if _, err = fmt.Fprintln(vf.w, Details()); err != nil {
+		return fmt.Errorf("writing version details: %w", err)
+	}
+
+	osExit(0)
+
+	return nil
+}
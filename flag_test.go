@@ -0,0 +1,97 @@
+package version_test
+
+import (
+	"flag"
+	"io"
+	"strings"
+	"testing"
+
+	"go.jlucktay.dev/version"
+)
+
+//nolint:paralleltest // Overrides the package seams.
+func TestVersionFlagPrintsAndExits(t *testing.T) {
+	stubRuntime(t)
+
+	var codes []int
+
+	version.Stub(t, version.OSExit, func(code int) {
+		codes = append(codes, code)
+	})
+
+	var out strings.Builder
+
+	flagSet := flag.NewFlagSet("test", flag.ContinueOnError)
+	flagSet.Var(version.VersionFlag(&out), "version", "print version information and exit")
+
+	if err := flagSet.Parse([]string{"-version"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := version.Details() + "\n"; out.String() != want {
+		t.Errorf("printed %q, want %q", out.String(), want)
+	}
+
+	if len(codes) != 1 || codes[0] != 0 {
+		t.Errorf("exited with %v, want a single exit with status 0", codes)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestVersionFlagFalseDoesNothing(t *testing.T) {
+	stubRuntime(t)
+	version.Stub(t, version.OSExit, func(int) {
+		t.Error("exited with the flag set to false")
+	})
+
+	var out strings.Builder
+
+	flagSet := flag.NewFlagSet("test", flag.ContinueOnError)
+	flagSet.Var(version.VersionFlag(&out), "version", "print version information and exit")
+
+	if err := flagSet.Parse([]string{"-version=false"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if out.Len() != 0 {
+		t.Errorf("printed %q, want nothing", out.String())
+	}
+}
+
+func TestVersionFlagRejectsNonBooleans(t *testing.T) {
+	t.Parallel()
+
+	flagSet := flag.NewFlagSet("test", flag.ContinueOnError)
+	flagSet.SetOutput(io.Discard)
+	flagSet.Var(version.VersionFlag(io.Discard), "version", "print version information and exit")
+
+	if err := flagSet.Parse([]string{"-version=maybe"}); err == nil {
+		t.Error("Parse() with a non-boolean value returned no error")
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestVersionFlagReportsWriteErrors(t *testing.T) {
+	stubRuntime(t)
+	version.Stub(t, version.OSExit, func(int) {
+		t.Error("exited despite failing to write")
+	})
+
+	if err := version.VersionFlag(&failingWriter{}).Set("true"); err == nil {
+		t.Error("Set() with a failing writer returned no error")
+	}
+}
+
+func TestVersionFlagDefault(t *testing.T) {
+	t.Parallel()
+
+	value := version.VersionFlag(io.Discard)
+
+	if got := value.String(); got != "false" {
+		t.Errorf("String() = %q, want %q", got, "false")
+	}
+
+	if bf, ok := value.(interface{ IsBoolFlag() bool }); !ok || !bf.IsBoolFlag() {
+		t.Error("VersionFlag() is not a boolean flag")
+	}
+}
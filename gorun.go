@@ -0,0 +1,50 @@
+package version
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// IsGoRun reports whether the currently executing binary appears to have been started with 'go run', rather than
+// built and installed beforehand.
+//
+// This is a heuristic based on where 'go run' leaves the binary it builds: a path of the form
+// '<tmp>/go-build<digits>/b001/exe/<name>', under the directory named by GOTMPDIR or else the system temporary
+// directory. It can be fooled by a binary deliberately placed at such a path, and will miss a 'go run' whose build
+// cache layout differs from that of the toolchains released so far. The '(devel)' main module version is not used as
+// a signal, as a plain 'go build' from a checkout records it too.
+func IsGoRun() bool {
+	exePath, err := runtimeLookups().executablePath()
+	if err != nil {
+		return false
+	}
+
+	exeDir := filepath.Dir(exePath)
+	workDir := filepath.Dir(filepath.Dir(exeDir))
+
+	if filepath.Base(exeDir) != "exe" || !strings.HasPrefix(filepath.Base(workDir), "go-build") {
+		return false
+	}
+
+	tmpDir := os.Getenv("GOTMPDIR")
+	if tmpDir == "" {
+		tmpDir = os.TempDir()
+	}
+
+	return sameDir(filepath.Dir(workDir), tmpDir)
+}
+
+// sameDir reports whether the two paths name the same directory, resolving any symbolic links such as the one from
+// '/var' to '/private/var' on macOS, and falling back to comparing the cleaned paths if either cannot be resolved.
+func sameDir(dir, other string) bool {
+	if resolved, err := filepath.EvalSymlinks(dir); err == nil {
+		dir = resolved
+	}
+
+	if resolved, err := filepath.EvalSymlinks(other); err == nil {
+		other = resolved
+	}
+
+	return filepath.Clean(dir) == filepath.Clean(other)
+}
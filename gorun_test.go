@@ -0,0 +1,66 @@
+package version_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.jlucktay.dev/version"
+)
+
+//nolint:paralleltest // Overrides the package seams and the environment.
+func TestIsGoRun(t *testing.T) {
+	tmpDir := t.TempDir()
+	otherDir := t.TempDir()
+
+	testCases := map[string]struct {
+		exePath string
+		want    bool
+	}{
+		"go run":                 {filepath.Join(tmpDir, "go-build1234567", "b001", "exe", "myapp"), true},
+		"installed binary":       {filepath.Join("/usr", "local", "bin", "myapp"), false},
+		"not under exe":          {filepath.Join(tmpDir, "go-build1234567", "b001", "bin", "myapp"), false},
+		"not a go-build dir":     {filepath.Join(tmpDir, "build1234567", "b001", "exe", "myapp"), false},
+		"outside the temp dir":   {filepath.Join(otherDir, "go-build1234567", "b001", "exe", "myapp"), false},
+		"nested in the temp dir": {filepath.Join(tmpDir, "x", "go-build1234567", "b001", "exe", "myapp"), false},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("GOTMPDIR", tmpDir)
+			version.Stub(t, version.OSExecutable, func() (string, error) {
+				return testCase.exePath, nil
+			})
+
+			if got := version.IsGoRun(); got != testCase.want {
+				t.Errorf("IsGoRun() from %q = %t, want %t", testCase.exePath, got, testCase.want)
+			}
+		})
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams and the environment.
+func TestIsGoRunFallsBackToSystemTempDir(t *testing.T) {
+	t.Setenv("GOTMPDIR", "")
+	version.Stub(t, version.OSExecutable, func() (string, error) {
+		return filepath.Join(os.TempDir(), "go-build42", "b001", "exe", "myapp"), nil
+	})
+
+	if !version.IsGoRun() {
+		t.Error("IsGoRun() under the system temporary directory = false, want true")
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestIsGoRunWithoutExecutable(t *testing.T) {
+	version.Stub(t, version.OSExecutable, func() (string, error) {
+		return "", errors.New("no executable")
+	})
+
+	if version.IsGoRun() {
+		t.Error("IsGoRun() without an executable path = true, want false")
+	}
+}
@@ -0,0 +1,100 @@
+package version
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidGoVersion is returned when a string cannot be parsed as a Go toolchain version.
+var ErrInvalidGoVersion = errors.New("invalid Go version")
+
+// The most components that a Go toolchain version may have, as in 'go1.22.3'.
+const goVersionParts = 3
+
+// A parsed Go toolchain version, where a release candidate or beta is a prerelease of the '.0' release it precedes.
+type goVersion struct {
+	parts      [goVersionParts]int
+	prerelease bool
+}
+
+// GoVersionAtLeast reports whether the Go toolchain recorded as having built the currently executing binary, as
+// returned by BuiltWith, is at least the given minimum.
+//
+// Both versions take the form reported by 'go version', such as 'go1.22.3', although the 'go' prefix may be left off.
+// Any missing minor or patch component counts as zero, so 'go1.22' is satisfied by 'go1.22.0' and anything newer.
+// A release candidate or beta such as 'go1.22rc1' sorts before the release it precedes, and anything after the
+// version itself, such as the ' X:boringcrypto' experiment suffix, is ignored.
+// An error is returned if either version cannot be parsed, including when the toolchain is 'unknown'.
+func GoVersionAtLeast(minimum string) (bool, error) {
+	wanted, err := parseGoVersion(minimum)
+	if err != nil {
+		return false, err
+	}
+
+	builtWith, err := parseGoVersion(BuiltWith())
+	if err != nil {
+		return false, err
+	}
+
+	return builtWith.compare(wanted) >= 0, nil
+}
+
+// parseGoVersion parses a Go toolchain version such as 'go1.22.3' or 'go1.21rc2'.
+func parseGoVersion(s string) (goVersion, error) {
+	var gv goVersion
+
+	trimmed := strings.TrimPrefix(strings.TrimSpace(s), "go")
+
+	if index := strings.IndexFunc(trimmed, func(r rune) bool { return r == ' ' || r == '\t' }); index >= 0 {
+		trimmed = trimmed[:index]
+	}
+
+	for _, marker := range []string{"rc", "beta"} {
+		if index := strings.Index(trimmed, marker); index >= 0 {
+			if _, ok := parseNumericIdentifier(trimmed[index+len(marker):]); !ok {
+				return goVersion{}, fmt.Errorf("%w %q: malformed %s number", ErrInvalidGoVersion, s, marker)
+			}
+
+			gv.prerelease = true
+			trimmed = trimmed[:index]
+
+			break
+		}
+	}
+
+	parts := strings.Split(trimmed, ".")
+	if len(parts) > goVersionParts {
+		return goVersion{}, fmt.Errorf("%w %q: want at most %d dot-separated numbers, got %d",
+			ErrInvalidGoVersion, s, goVersionParts, len(parts))
+	}
+
+	for index, part := range parts {
+		n, ok := parseNumericIdentifier(part)
+		if !ok {
+			return goVersion{}, fmt.Errorf("%w %q: malformed component %q", ErrInvalidGoVersion, s, part)
+		}
+
+		gv.parts[index] = n
+	}
+
+	return gv, nil
+}
+
+// compare returns -1, 0, or 1 depending on whether gv is older than, the same as, or newer than other.
+func (gv goVersion) compare(other goVersion) int {
+	for index := range gv.parts {
+		if cmp := compareInts(gv.parts[index], other.parts[index]); cmp != 0 {
+			return cmp
+		}
+	}
+
+	switch {
+	case gv.prerelease == other.prerelease:
+		return 0
+	case gv.prerelease:
+		return -1
+	default:
+		return 1
+	}
+}
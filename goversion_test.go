@@ -0,0 +1,66 @@
+package version_test
+
+import (
+	"errors"
+	"testing"
+
+	"go.jlucktay.dev/version"
+)
+
+//nolint:paralleltest // Overrides the package seams.
+func TestGoVersionAtLeast(t *testing.T) {
+	stubRuntime(t)
+
+	testCases := map[string]struct {
+		builtWith, minimum string
+		want               bool
+	}{
+		"same version":                  {"go1.20.2", "go1.20.2", true},
+		"older patch":                   {"go1.20.2", "go1.20.3", false},
+		"newer minor":                   {"go1.21.0", "go1.20.9", true},
+		"numeric not lexical":           {"go1.10", "go1.9", true},
+		"missing components are zero":   {"go1.22.0", "go1.22", true},
+		"without the prefix":            {"go1.20.2", "1.20", true},
+		"release candidate before":      {"go1.22rc1", "go1.22", false},
+		"release candidate after older": {"go1.22rc1", "go1.21.9", true},
+		"beta before rc":                {"go1.22beta1", "go1.22rc1", true},
+		"experiment suffix is ignored":  {"go1.20.2 X:boringcrypto", "go1.20.2", true},
+		"newer major":                   {"go2.0", "go1.99", true},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(name, func(t *testing.T) {
+			version.SetBuiltWith(testCase.builtWith)
+
+			got, err := version.GoVersionAtLeast(testCase.minimum)
+			if err != nil {
+				t.Fatalf("GoVersionAtLeast(%q) error = %v", testCase.minimum, err)
+			}
+
+			if got != testCase.want {
+				t.Errorf("GoVersionAtLeast(%q) built with %q = %t, want %t",
+					testCase.minimum, testCase.builtWith, got, testCase.want)
+			}
+		})
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestGoVersionAtLeastRejectsMalformedVersions(t *testing.T) {
+	stubRuntime(t)
+
+	for _, minimum := range []string{"", "go1.x", "go1.2.3.4", "go1.22rc", "go1.22betaX", "go-1"} {
+		if _, err := version.GoVersionAtLeast(minimum); !errors.Is(err, version.ErrInvalidGoVersion) {
+			t.Errorf("GoVersionAtLeast(%q) error = %v, want %v", minimum, err, version.ErrInvalidGoVersion)
+		}
+	}
+
+	version.SetBuiltWith("unknown")
+
+	if _, err := version.GoVersionAtLeast("go1.20"); !errors.Is(err, version.ErrInvalidGoVersion) {
+		t.Errorf("GoVersionAtLeast() built with an unknown toolchain error = %v, want %v",
+			err, version.ErrInvalidGoVersion)
+	}
+}
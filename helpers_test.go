@@ -0,0 +1,130 @@
+package version_test
+
+import (
+	"bytes"
+	"flag"
+	"io/fs"
+	"os"
+	"os/user"
+	"path/filepath"
+	"runtime/debug"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"go.jlucktay.dev/version"
+)
+
+// The values returned by the stubbed runtime lookups.
+const (
+	testExePath    = "/opt/testapp/bin/testapp"
+	testExecutable = "testapp"
+	testVersion    = "v1.2.3"
+	testUser       = "tester"
+	testRevision   = "0123456789abcdef0123456789abcdef01234567"
+	testGoVersion  = "go1.20.2"
+	testBuildDate  = "2006-01-02T15:04:05Z"
+	testModulePath = "example.com/testapp"
+	testHostname   = "testhost"
+)
+
+// testModTime is the modification time of the stubbed executable, matching testBuildDate.
+func testModTime() time.Time {
+	return time.Date(2006, time.January, 2, 15, 4, 5, 0, time.UTC)
+}
+
+// testBuildInfo returns the build info of the stubbed executable.
+func testBuildInfo() *debug.BuildInfo {
+	return &debug.BuildInfo{
+		GoVersion: testGoVersion,
+		Path:      testModulePath,
+		Main: debug.Module{
+			Path:    testModulePath,
+			Version: testVersion,
+		},
+		Deps: []*debug.Module{
+			{Path: "example.com/dep", Version: "v0.1.0", Sum: "h1:dep="},
+			{
+				Path:    "example.com/old",
+				Version: "v1.0.0",
+				Replace: &debug.Module{Path: "example.com/new", Version: "v1.0.1"},
+			},
+		},
+		Settings: []debug.BuildSetting{
+			{Key: "-compiler", Value: "gc"},
+			{Key: "CGO_ENABLED", Value: "1"},
+			{Key: "GOOS", Value: "linux"},
+			{Key: "vcs", Value: "git"},
+			{Key: "vcs.revision", Value: testRevision},
+			{Key: "vcs.modified", Value: "false"},
+		},
+	}
+}
+
+// stubRuntime replaces every runtime lookup behind the fallbacks with a fixed value until the end of the test.
+func stubRuntime(tb testing.TB) {
+	tb.Helper()
+
+	version.Stub(tb, version.OSExecutable, func() (string, error) {
+		return testExePath, nil
+	})
+
+	version.Stub(tb, version.OSStat, func(string) (fs.FileInfo, error) {
+		return fstest.MapFS{testExecutable: {ModTime: testModTime()}}.Stat(testExecutable)
+	})
+
+	version.Stub(tb, version.UserCurrent, func() (*user.User, error) {
+		return &user.User{Username: testUser}, nil
+	})
+
+	version.Stub(tb, version.ReadBuildInfo, func() (*debug.BuildInfo, bool) {
+		return testBuildInfo(), true
+	})
+
+	version.Stub(tb, version.OSHostname, func() (string, error) {
+		return testHostname, nil
+	})
+}
+
+// stubBuildInfo replaces the build info of the stubbed executable with the result of calling modify on a copy of it.
+func stubBuildInfo(t *testing.T, modify func(*debug.BuildInfo)) {
+	t.Helper()
+
+	version.Stub(t, version.ReadBuildInfo, func() (*debug.BuildInfo, bool) {
+		bi := testBuildInfo()
+		modify(bi)
+
+		return bi, true
+	})
+}
+
+// Rewrites the golden files under testdata with the output of the tests, as in 'go test ./... -update'.
+//
+//nolint:gochecknoglobals // Flags are registered at package initialisation.
+var update = flag.Bool("update", false, "rewrite the golden files under testdata")
+
+// assertGolden compares got with the contents of the named golden file under testdata.
+func assertGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name+".golden")
+
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(path, got, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading golden file: %v", err)
+	}
+
+	if !bytes.Equal(got, want) {
+		t.Errorf("output does not match %s:\ngot:\n%s\nwant:\n%s", path, got, want)
+	}
+}
@@ -0,0 +1,132 @@
+package version
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Handler returns an 'http.Handler' that responds to GET requests with the JSON form of the Info describing the caller.
+// If the request's Accept header ranks 'text/plain' above 'application/json' by quality value, the string from Details
+// is returned instead.
+// Requests using any other method get a '405 Method Not Allowed' response.
+// Any options given, such as WithOmit to keep some fields from a public-facing endpoint, apply to both forms.
+func Handler(opts ...Option) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+			return
+		}
+
+		if prefersPlainText(r.Header.Values("Accept")) {
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			fmt.Fprintln(w, Details(opts...))
+
+			return
+		}
+
+		b, err := JSON(opts...)
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", mediaTypeJSON)
+		fmt.Fprintf(w, "%s\n", b)
+	})
+}
+
+// The media types that Handler can respond with.
+const (
+	mediaTypeJSON      = "application/json"
+	mediaTypePlainText = "text/plain"
+)
+
+// prefersPlainText reports whether the given Accept header values rank 'text/plain' above 'application/json', going by
+// the quality value of the most specific media range matching each. JSON wins a tie, including when there is no
+// Accept header at all.
+func prefersPlainText(accept []string) bool {
+	return acceptQuality(accept, mediaTypePlainText) > acceptQuality(accept, mediaTypeJSON)
+}
+
+// acceptQuality returns the quality value that the given Accept header values give to the media type, from the most
+// specific of the matching media ranges, or zero if none match.
+func acceptQuality(accept []string, mediaType string) float64 {
+	const (
+		specificityAny = iota + 1
+		specificityType
+		specificityExact
+	)
+
+	bestSpecificity, quality := 0, 0.0
+	typeRange := mediaType[:strings.Index(mediaType, "/")] + "/*"
+
+	for _, value := range accept {
+		for _, mediaRange := range strings.Split(value, ",") {
+			parsed, params, err := mime.ParseMediaType(mediaRange)
+			if err != nil {
+				continue
+			}
+
+			specificity := 0
+
+			switch parsed {
+			case mediaType:
+				specificity = specificityExact
+			case typeRange:
+				specificity = specificityType
+			case "*/*":
+				specificity = specificityAny
+			}
+
+			if specificity <= bestSpecificity {
+				continue
+			}
+
+			bestSpecificity, quality = specificity, 1
+
+			if q, ok := params["q"]; ok {
+				if parsedQ, err := strconv.ParseFloat(q, 64); err == nil {
+					quality = parsedQ
+				}
+			}
+		}
+	}
+
+	return quality
+}
+
+// Middleware wraps the next handler so that every response carries an 'X-Version' header, and an 'X-Commit' header
+// unless the WithoutCommitHeader option is given.
+// The header values are derived once, when Middleware is called, with the same fallbacks that Details uses.
+func Middleware(next http.Handler, opts ...Option) http.Handler {
+	o := newOptions(opts)
+	i := Current(opts...)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Version", i.Version)
+
+		if !o.omitCommitHeader {
+			w.Header().Set("X-Commit", i.Commit)
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// UserAgent returns a string suitable for the User-Agent header of outbound HTTP requests, in the conventional
+// 'product/version (comment)' form:
+//
+//	<executable>/<version> (commit <commit>; <builtWith>)
+//
+// where the version has any leading 'v' or 'V' removed and the commit is abbreviated to ShortCommitLength characters.
+func UserAgent() string {
+	i := Current(WithShortCommit(ShortCommitLength))
+
+	return fmt.Sprintf("%s/%s (commit %s; %s)", i.Executable, trimVPrefix(i.Version), i.Commit, i.BuiltWith)
+}
@@ -0,0 +1,138 @@
+package version_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.jlucktay.dev/version"
+)
+
+//nolint:paralleltest // Overrides the package seams.
+func TestHandlerNegotiatesContentType(t *testing.T) {
+	stubRuntime(t)
+
+	encoded, err := version.JSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		jsonBody = string(encoded) + "\n"
+		textBody = version.Details() + "\n"
+	)
+
+	testCases := map[string]struct {
+		accept      string
+		contentType string
+		body        string
+	}{
+		"no accept header":             {"", "application/json", jsonBody},
+		"json":                         {"application/json", "application/json", jsonBody},
+		"plain text":                   {"text/plain", "text/plain; charset=utf-8", textBody},
+		"update source tie":            {"application/json, text/plain", "application/json", jsonBody},
+		"tie in the other order":       {"text/plain, application/json", "application/json", jsonBody},
+		"text ranked higher":           {"application/json;q=0.5, text/plain", "text/plain; charset=utf-8", textBody},
+		"text wildcard ranked higher":  {"text/*;q=0.9, application/json;q=0.8", "text/plain; charset=utf-8", textBody},
+		"anything":                     {"*/*", "application/json", jsonBody},
+		"text refused":                 {"text/plain;q=0, */*;q=0.1", "application/json", jsonBody},
+		"exact range beats a wildcard": {"text/plain;q=0.2, */*;q=0.9", "application/json", jsonBody},
+		"browser":                      {"text/html, */*;q=0.8", "application/json", jsonBody},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/version", nil)
+			if testCase.accept != "" {
+				req.Header.Set("Accept", testCase.accept)
+			}
+
+			rec := httptest.NewRecorder()
+			version.Handler().ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusOK {
+				t.Errorf("status = %d, want %d", rec.Code, http.StatusOK)
+			}
+
+			if got := rec.Header().Get("Content-Type"); got != testCase.contentType {
+				t.Errorf("Content-Type = %q, want %q", got, testCase.contentType)
+			}
+
+			if got := rec.Body.String(); got != testCase.body {
+				t.Errorf("body = %q, want %q", got, testCase.body)
+			}
+		})
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestHandlerRejectsOtherMethods(t *testing.T) {
+	stubRuntime(t)
+
+	rec := httptest.NewRecorder()
+	version.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/version", nil))
+
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusMethodNotAllowed)
+	}
+
+	if got := rec.Header().Get("Allow"); got != http.MethodGet {
+		t.Errorf("Allow = %q, want %q", got, http.MethodGet)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestMiddlewareStampsHeaders(t *testing.T) {
+	stubRuntime(t)
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+
+	testCases := map[string]struct {
+		opts   []version.Option
+		commit string
+	}{
+		"default":               {nil, testRevision},
+		"short commit":          {[]version.Option{version.WithShortCommit(7)}, "0123456"},
+		"without commit header": {[]version.Option{version.WithoutCommitHeader()}, ""},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			version.Middleware(next, testCase.opts...).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			if rec.Code != http.StatusTeapot {
+				t.Errorf("status = %d, want the next handler to have run", rec.Code)
+			}
+
+			if got := rec.Header().Get("X-Version"); got != testVersion {
+				t.Errorf("X-Version = %q, want %q", got, testVersion)
+			}
+
+			if got := rec.Header().Get("X-Commit"); got != testCase.commit {
+				t.Errorf("X-Commit = %q, want %q", got, testCase.commit)
+			}
+		})
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestUserAgent(t *testing.T) {
+	stubRuntime(t)
+
+	if got, want := version.UserAgent(), "testapp/1.2.3 (commit 0123456; go1.20.2)"; got != want {
+		t.Errorf("UserAgent() = %q, want %q", got, want)
+	}
+
+	version.SetVersion("2.0.0-rc.1")
+
+	if got, want := version.UserAgent(), "testapp/2.0.0-rc.1 (commit 0123456; go1.20.2)"; got != want {
+		t.Errorf("UserAgent() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,81 @@
+package version
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// JSON returns the JSON encoding of the Info describing the caller.
+// The key names and their order are documented on the Info type, less any fields dropped with WithOmit, and followed by
+// a 'dependencies' key if WithDependencies is given.
+func JSON(opts ...Option) ([]byte, error) {
+	return std.JSON(opts...)
+}
+
+// JSONIndent returns the same JSON encoding of the Info describing the caller as JSON, indented for reading on a
+// terminal in the manner of 'json.MarshalIndent'. Only the whitespace differs between the two forms.
+func JSONIndent(prefix, indent string, opts ...Option) ([]byte, error) {
+	b, err := JSON(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+
+	if err := json.Indent(&buf, b, prefix, indent); err != nil {
+		return nil, fmt.Errorf("indenting version info: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// marshalJSON encodes the given Info as a JSON object, in the same form as 'json.Marshal' but without the keys of any
+// fields dropped by the given options, and with any dependencies asked for by them.
+func marshalJSON(i Info, o options) ([]byte, error) {
+	var buf bytes.Buffer
+
+	buf.WriteByte('{')
+
+	for _, field := range o.withoutOmitted(i.allFields()) {
+		if field.Value == "" && omitsEmpty(field.Name) {
+			continue
+		}
+
+		key, err := json.Marshal(field.Name)
+		if err != nil {
+			return nil, fmt.Errorf("marshalling version info: %w", err)
+		}
+
+		value, err := json.Marshal(field.Value)
+		if err != nil {
+			return nil, fmt.Errorf("marshalling version info: %w", err)
+		}
+
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+
+	if o.dependencies {
+		deps, err := json.Marshal(Dependencies())
+		if err != nil {
+			return nil, fmt.Errorf("marshalling version info: %w", err)
+		}
+
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+
+		buf.WriteString(`"dependencies":`)
+		buf.Write(deps)
+	}
+
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,203 @@
+package version_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+
+	"go.jlucktay.dev/version"
+)
+
+//nolint:paralleltest // Overrides the package seams.
+func TestJSONGolden(t *testing.T) {
+	stubRuntime(t)
+
+	// The platform fields differ between the machines running the tests, so are checked separately.
+	got, err := version.JSON(version.WithOmit(version.FieldOS, version.FieldArch, version.FieldRuntimeGoVersion))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertGolden(t, "json", got)
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestJSONIncludesPlatformFields(t *testing.T) {
+	stubRuntime(t)
+
+	got, err := version.JSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded map[string]string
+	if err := json.Unmarshal(got, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	for key, want := range map[string]string{
+		version.FieldOS:               runtime.GOOS,
+		version.FieldArch:             runtime.GOARCH,
+		version.FieldRuntimeGoVersion: runtime.Version(),
+	} {
+		if decoded[key] != want {
+			t.Errorf("JSON() %s = %q, want %q", key, decoded[key], want)
+		}
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestJSONRoundTripsCurrent(t *testing.T) {
+	stubRuntime(t)
+
+	got, err := version.JSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded version.Info
+	if err := json.Unmarshal(got, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := version.Current(); decoded != want {
+		t.Errorf("decoded JSON() =\n%#v\nwant\n%#v", decoded, want)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestJSONFillsFallbacksAroundPartialLdflags(t *testing.T) {
+	stubRuntime(t)
+	version.SetVersion("v4.5.6")
+
+	got, err := version.JSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded map[string]string
+	if err := json.Unmarshal(got, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	for key, want := range map[string]string{
+		version.FieldExecutable: testExecutable,
+		version.FieldVersion:    "v4.5.6",
+		version.FieldBuiltBy:    testUser,
+		version.FieldCommit:     testRevision,
+		version.FieldBuiltWith:  testGoVersion,
+		version.FieldBuildDate:  testBuildDate,
+	} {
+		if decoded[key] != want {
+			t.Errorf("JSON() %s = %q, want %q", key, decoded[key], want)
+		}
+	}
+
+	if strings.Contains(string(got), `""`) {
+		t.Errorf("JSON() = %s, want no empty values", got)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestJSONIndentHasSameDataAsJSON(t *testing.T) {
+	stubRuntime(t)
+
+	opts := []version.Option{version.WithHostname(), version.WithModulePath()}
+
+	compact, err := version.JSON(opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	indented, err := version.JSONIndent("", "\t", opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(string(indented), "{\n\t\"executable\": ") {
+		t.Errorf("JSONIndent() =\n%s\nwant each key on its own indented line", indented)
+	}
+
+	var fromCompact, fromIndented version.Info
+
+	if err := json.Unmarshal(compact, &fromCompact); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := json.Unmarshal(indented, &fromIndented); err != nil {
+		t.Fatal(err)
+	}
+
+	if fromCompact != fromIndented {
+		t.Errorf("JSONIndent() decoded to\n%#v\nwant the same as JSON()\n%#v", fromIndented, fromCompact)
+	}
+
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, indented); err != nil {
+		t.Fatal(err)
+	}
+
+	if buf.String() != string(compact) {
+		t.Errorf("JSONIndent() compacted = %s, want %s", buf.String(), compact)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestJSONIndentWithPrefix(t *testing.T) {
+	stubRuntime(t)
+
+	got, err := version.JSONIndent("> ", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for index, line := range strings.Split(string(got), "\n")[1:] {
+		if !strings.HasPrefix(line, "> ") {
+			t.Errorf("JSONIndent() line %d = %q, want the prefix", index+2, line)
+		}
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestJSONWithDependencies(t *testing.T) {
+	stubRuntime(t)
+
+	got, err := version.JSON(version.WithDependencies())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded struct {
+		Version      string           `json:"version"`
+		Dependencies []version.Module `json:"dependencies"`
+	}
+
+	if err := json.Unmarshal(got, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []version.Module{
+		{Path: "example.com/dep", Version: "v0.1.0", Sum: "h1:dep="},
+		{Path: "example.com/old", Version: "v1.0.0", Replace: &version.Module{Path: "example.com/new", Version: "v1.0.1"}},
+	}
+
+	if decoded.Version != testVersion || !reflect.DeepEqual(decoded.Dependencies, want) {
+		t.Errorf("JSON(WithDependencies()) = %s, want the version with dependencies %v", got, want)
+	}
+
+	if !strings.HasSuffix(string(got), `"replace":{"path":"example.com/new","version":"v1.0.1"}}]}`) {
+		t.Errorf("JSON(WithDependencies()) = %s, want the dependencies last", got)
+	}
+
+	plain, err := version.JSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(string(plain), "dependencies") {
+		t.Errorf("JSON() = %s, want the dependencies left out by default", plain)
+	}
+}
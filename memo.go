@@ -0,0 +1,205 @@
+package version
+
+import (
+	"errors"
+	"runtime/debug"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// lookups caches the results of the runtime lookups behind the fallback values. None of them can change over the life
+// of the process, so each is made at most once, however many times the values are derived.
+type lookups struct {
+	exeOnce sync.Once
+	exePath string
+	exeErr  error
+
+	modTimeOnce sync.Once
+	modTime     time.Time
+	modTimeErr  error
+
+	userOnce sync.Once
+	userDone uint32
+	userName string
+	userErr  error
+
+	buildInfoOnce sync.Once
+	buildInfo     *debug.BuildInfo
+	buildInfoOK   bool
+}
+
+// errLookupAbandoned is returned by usernameBefore when it gives up waiting for the lookup.
+var errLookupAbandoned = errors.New("lookup abandoned")
+
+// The lookups shared by every derivation, which tests replace so that they can override the seams in between.
+//
+//nolint:gochecknoglobals // Process-wide cache of values that cannot change.
+var (
+	memoMu sync.Mutex
+	memo   = &lookups{}
+)
+
+// The generation of the explicit values, which is bumped whenever one of them changes, so that any Info derived from
+// an earlier generation is derived again.
+//
+//nolint:gochecknoglobals // Shared by every Build, as the setters and LoadVersionFrom are themselves global.
+var generation uint64
+
+// invalidate discards every cached derivation, for the next call to derive afresh.
+func invalidate() {
+	atomic.AddUint64(&generation, 1)
+}
+
+// derivation caches the Info derived for a Build with its own options, before any field-level options are applied,
+// along with the errors from the lookups behind it.
+type derivation struct {
+	once       sync.Once
+	generation uint64
+	info       Info
+	errs       []error
+}
+
+// derivationKey holds the options that change how the fallbacks are derived, as opposed to how the result is rendered
+// or which fields are redacted, so that two sets of options with the same key derive the same Info.
+type derivationKey struct {
+	dateLayout     string
+	envPrefix      string
+	unknown        string
+	unknownVersion string
+	env            bool
+	utc            bool
+	keepExeSuffix  bool
+	executablePath bool
+	modulePath     bool
+}
+
+// derivationKey returns the key for the options, or false if the Info they derive must not be cached, as the hostname
+// is looked up afresh each time, and a lookup abandoned by DetailsContext would leave its fallback in place for good.
+func (o options) derivationKey() (derivationKey, bool) {
+	if o.hostname || o.userLookupDone != nil {
+		return derivationKey{}, false
+	}
+
+	return derivationKey{
+		dateLayout:     o.dateLayout,
+		envPrefix:      o.envPrefix,
+		unknown:        o.unknown,
+		unknownVersion: o.unknownVersion,
+		env:            o.env,
+		utc:            o.utc,
+		keepExeSuffix:  o.keepExeSuffix,
+		executablePath: o.executablePath,
+		modulePath:     o.modulePath,
+	}, true
+}
+
+// runtimeLookups returns the current cache of runtime lookups.
+func runtimeLookups() *lookups {
+	memoMu.Lock()
+	defer memoMu.Unlock()
+
+	return memo
+}
+
+// executablePath returns the path of the currently executing binary, from 'os.Executable()'.
+func (l *lookups) executablePath() (string, error) {
+	l.exeOnce.Do(func() {
+		l.exePath, l.exeErr = osExecutable()
+	})
+
+	return l.exePath, l.exeErr
+}
+
+// executableModTime returns the modification time of the currently executing binary, from calling 'os.Stat' on the
+// path returned by executablePath.
+func (l *lookups) executableModTime() (time.Time, error) {
+	l.modTimeOnce.Do(func() {
+		exePath, err := l.executablePath()
+		if err != nil {
+			l.modTimeErr = err
+
+			return
+		}
+
+		fi, err := osStat(exePath)
+		if err != nil {
+			l.modTimeErr = err
+
+			return
+		}
+
+		l.modTime = fi.ModTime()
+	})
+
+	return l.modTime, l.modTimeErr
+}
+
+// username returns the name of the user running the binary, from 'user.Current()', or else their numeric user ID in
+// the form 'uid:1000', as in a container without an '/etc/passwd' file. The user ID is not used on Windows, where it
+// has no meaning. Any error from 'user.Current()' is returned even when the user ID stands in for the name, so that
+// the failure is still reported.
+func (l *lookups) username() (string, error) {
+	l.userOnce.Do(func() {
+		u, err := userCurrent()
+		if err != nil {
+			l.userErr = err
+
+			if uid := osGetuid(); runtimeGOOS != "windows" && uid >= 0 {
+				l.userName = "uid:" + strconv.Itoa(uid)
+			}
+
+			return
+		}
+
+		l.userName = u.Username
+	})
+
+	atomic.StoreUint32(&l.userDone, 1)
+
+	return l.userName, l.userErr
+}
+
+// usernameBefore is like username, but gives up if done is closed before the lookup returns. The lookup carries on in
+// the background, so that its result is still cached for later calls. A nil channel waits for as long as it takes,
+// and a result that is already cached is returned even if done is closed.
+func (l *lookups) usernameBefore(done <-chan struct{}) (string, error) {
+	if done == nil || atomic.LoadUint32(&l.userDone) == 1 {
+		return l.username()
+	}
+
+	select {
+	case <-done:
+		return "", errLookupAbandoned
+	default:
+	}
+
+	type result struct {
+		name string
+		err  error
+	}
+
+	results := make(chan result, 1)
+
+	go func() {
+		name, err := l.username()
+		results <- result{name, err}
+	}()
+
+	select {
+	case r := <-results:
+		return r.name, r.err
+	case <-done:
+		return "", errLookupAbandoned
+	}
+}
+
+// readBuildInfo returns the build info embedded in the binary, from 'debug.ReadBuildInfo()'.
+func (l *lookups) readBuildInfo() (*debug.BuildInfo, bool) {
+	l.buildInfoOnce.Do(func() {
+		l.buildInfo, l.buildInfoOK = readBuildInfo()
+	})
+
+	return l.buildInfo, l.buildInfoOK
+}
@@ -0,0 +1,237 @@
+package version_test
+
+import (
+	"io/fs"
+	"os/user"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"testing/fstest"
+
+	"go.jlucktay.dev/version"
+)
+
+// seamCalls counts the calls made to each of the expensive seams behind the fallbacks.
+type seamCalls struct {
+	executable, stat, user, buildInfo int64
+}
+
+// countSeamCalls replaces the expensive seams with ones that give the same values as stubRuntime, counting the calls
+// made to each until the end of the test.
+func countSeamCalls(tb testing.TB) *seamCalls {
+	tb.Helper()
+
+	calls := &seamCalls{}
+
+	version.Stub(tb, version.OSExecutable, func() (string, error) {
+		atomic.AddInt64(&calls.executable, 1)
+
+		return testExePath, nil
+	})
+
+	version.Stub(tb, version.OSStat, func(string) (fs.FileInfo, error) {
+		atomic.AddInt64(&calls.stat, 1)
+
+		return fstest.MapFS{testExecutable: {ModTime: testModTime()}}.Stat(testExecutable)
+	})
+
+	version.Stub(tb, version.UserCurrent, func() (*user.User, error) {
+		atomic.AddInt64(&calls.user, 1)
+
+		return &user.User{Username: testUser}, nil
+	})
+
+	version.Stub(tb, version.ReadBuildInfo, func() (*debug.BuildInfo, bool) {
+		atomic.AddInt64(&calls.buildInfo, 1)
+
+		return testBuildInfo(), true
+	})
+
+	return calls
+}
+
+// assertCalledOnce fails the test unless each of the expensive seams was called exactly once.
+func (c *seamCalls) assertCalledOnce(t *testing.T) {
+	t.Helper()
+
+	for name, count := range map[string]int64{
+		"os.Executable":       atomic.LoadInt64(&c.executable),
+		"os.Stat":             atomic.LoadInt64(&c.stat),
+		"user.Current":        atomic.LoadInt64(&c.user),
+		"debug.ReadBuildInfo": atomic.LoadInt64(&c.buildInfo),
+	} {
+		if count != 1 {
+			t.Errorf("%s was called %d times, want once", name, count)
+		}
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestSeamsAreCalledOnce(t *testing.T) {
+	calls := countSeamCalls(t)
+
+	var wg sync.WaitGroup
+
+	for worker := 0; worker < 10; worker++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for call := 0; call < 100; call++ {
+				_ = version.Short()
+				_ = version.Details()
+				_ = version.Verbose()
+
+				if _, err := version.JSON(version.WithModulePath()); err != nil {
+					t.Error(err)
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	calls.assertCalledOnce(t)
+
+	if got, want := version.Short(), testExecutable+" "+testVersion+" (0123456)"; got != want {
+		t.Errorf("Short() = %q, want %q", got, want)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestSettersInvalidateCachedInfo(t *testing.T) {
+	calls := countSeamCalls(t)
+
+	if got := version.Version(); got != testVersion {
+		t.Fatalf("Version() = %q, want %q", got, testVersion)
+	}
+
+	version.SetVersion("v2.0.0")
+
+	if got := version.Version(); got != "v2.0.0" {
+		t.Errorf("Version() after SetVersion() = %q, want %q", got, "v2.0.0")
+	}
+
+	version.SetVersion("")
+
+	if got := version.Version(); got != testVersion {
+		t.Errorf("Version() after clearing it = %q, want the fallback %q again", got, testVersion)
+	}
+
+	// The lookups themselves cannot change, so are not made again.
+	calls.assertCalledOnce(t)
+}
+
+// BenchmarkShort measures the cost of Short once the Info has been derived and cached.
+func BenchmarkShort(b *testing.B) {
+	stubRuntime(b)
+
+	_ = version.Short()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for index := 0; index < b.N; index++ {
+		_ = version.Short()
+	}
+}
+
+// BenchmarkShortUncached measures the cost of Short when every call derives the Info and makes the lookups afresh, as
+// each call did before the results were cached.
+func BenchmarkShortUncached(b *testing.B) {
+	stubRuntime(b)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for index := 0; index < b.N; index++ {
+		version.Reset()
+
+		_ = version.Short()
+	}
+}
+
+// forbidLookups replaces the seams behind the executable, builder, and build date fallbacks with ones that fail the
+// test if called, until the end of the test.
+func forbidLookups(t *testing.T) {
+	t.Helper()
+
+	version.Stub(t, version.OSExecutable, func() (string, error) {
+		t.Error("os.Executable was called")
+
+		return testExePath, nil
+	})
+
+	version.Stub(t, version.OSStat, func(string) (fs.FileInfo, error) {
+		t.Error("os.Stat was called")
+
+		return fstest.MapFS{testExecutable: {ModTime: testModTime()}}.Stat(testExecutable)
+	})
+
+	version.Stub(t, version.UserCurrent, func() (*user.User, error) {
+		t.Error("user.Current was called")
+
+		return &user.User{Username: testUser}, nil
+	})
+
+	version.Stub(t, version.OSGetuid, func() int {
+		t.Error("os.Getuid was called")
+
+		return 0
+	})
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestNoLookupsForExplicitValues(t *testing.T) {
+	stubRuntime(t)
+	forbidLookups(t)
+
+	version.SetExecutable("myapp")
+	version.SetVersion("v1.0.0")
+	version.SetBuiltBy("ci")
+	version.SetCommit("abc1234")
+	version.SetBuiltWith("go1.20.2")
+	version.SetBuildDate("2006-01-02T15:04:05Z")
+
+	want := "myapp v1.0.0 built by ci from commit abc1234 with go1.20.2 at 2006-01-02T15:04:05Z."
+	if got := version.Details(); got != want {
+		t.Errorf("Details() = %q, want %q", got, want)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams and the environment.
+func TestNoLookupsForEnvironmentValues(t *testing.T) {
+	stubRuntime(t)
+	forbidLookups(t)
+
+	t.Setenv("MYAPP_EXECUTABLE", "envapp")
+	t.Setenv("MYAPP_BUILT_BY", "envuser")
+	t.Setenv("MYAPP_BUILD_DATE", "2001-01-01T00:00:00Z")
+
+	got := version.Current(version.WithEnvPrefix("MYAPP_"))
+
+	if got.Executable != "envapp" || got.BuiltBy != "envuser" || got.BuildDate != "2001-01-01T00:00:00Z" {
+		t.Errorf("Current(WithEnvPrefix()) = %#v, want the values from the environment", got)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestOnlyMissingFieldsAreLookedUp(t *testing.T) {
+	stubRuntime(t)
+
+	version.Stub(t, version.UserCurrent, func() (*user.User, error) {
+		t.Error("user.Current was called")
+
+		return nil, fs.ErrPermission
+	})
+
+	version.SetBuiltBy("ci")
+
+	// The builder is known, but the executable and build date still come from the runtime.
+	got := version.Current()
+	if got.BuiltBy != "ci" || got.Executable != testExecutable || got.BuildDate != testBuildDate {
+		t.Errorf("Current() = %#v, want the explicit builder with the other fallbacks", got)
+	}
+}
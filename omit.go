@@ -0,0 +1,44 @@
+package version
+
+// WithOmit drops the named fields, as given by the Field* constants, from the output of Details, Fprint, JSON,
+// Verbose, and Fields. This keeps values such as the name of the user who built the binary out of public-facing
+// endpoints.
+//
+// In the sentence from Details, the clause for each omitted field is skipped entirely, as in
+// 'myapp v1.2.3 from commit 0123456 with go1.20.2 at 2006-01-02T15:04:05Z.' when builtBy is omitted. A format given
+// with WithFormat receives an empty string in place of each omitted value.
+func WithOmit(fields ...string) Option {
+	return func(o *options) {
+		if o.omit == nil {
+			o.omit = make(map[string]bool, len(fields))
+		}
+
+		for _, field := range fields {
+			o.omit[field] = true
+		}
+	}
+}
+
+// blankOmitted returns a copy of the given Info with each field dropped by WithOmit set to an empty string.
+func (o options) blankOmitted(i Info) Info {
+	for name, value := range i.fieldRefs() {
+		if o.omit[name] {
+			*value = ""
+		}
+	}
+
+	return i
+}
+
+// withoutOmitted returns the given fields less any that were dropped by WithOmit.
+func (o options) withoutOmitted(fields []Field) []Field {
+	kept := make([]Field, 0, len(fields))
+
+	for _, field := range fields {
+		if !o.omit[field.Name] {
+			kept = append(kept, field)
+		}
+	}
+
+	return kept
+}
@@ -0,0 +1,84 @@
+package version_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"go.jlucktay.dev/version"
+)
+
+//nolint:paralleltest // Overrides the package seams.
+func TestWithOmitInDetails(t *testing.T) {
+	stubRuntime(t)
+
+	testCases := map[string]struct {
+		fields []string
+		want   string
+	}{
+		"builtBy": {
+			fields: []string{version.FieldBuiltBy},
+			want:   "testapp v1.2.3 from commit 0123456 with go1.20.2 at 2006-01-02T15:04:05Z.",
+		},
+		"builtBy and commit": {
+			fields: []string{version.FieldBuiltBy, version.FieldCommit},
+			want:   "testapp v1.2.3 with go1.20.2 at 2006-01-02T15:04:05Z.",
+		},
+		"every clause": {
+			fields: []string{version.FieldBuiltBy, version.FieldCommit, version.FieldBuiltWith, version.FieldBuildDate},
+			want:   "testapp v1.2.3.",
+		},
+		"unknown field names are ignored": {
+			fields: []string{"nonsense"},
+			want:   version.Details(),
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(name, func(t *testing.T) {
+			if got := version.Details(version.WithOmit(testCase.fields...)); got != testCase.want {
+				t.Errorf("Details(WithOmit(%q)) = %q, want %q", testCase.fields, got, testCase.want)
+			}
+		})
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestWithOmitInJSON(t *testing.T) {
+	stubRuntime(t)
+
+	got, err := version.JSON(version.WithOmit(version.FieldBuiltBy))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded map[string]string
+	if err := json.Unmarshal(got, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	if value, ok := decoded[version.FieldBuiltBy]; ok {
+		t.Errorf("JSON(WithOmit(builtBy)) has builtBy = %q, want it absent", value)
+	}
+
+	if decoded[version.FieldVersion] != testVersion {
+		t.Errorf("JSON(WithOmit(builtBy)) version = %q, want %q", decoded[version.FieldVersion], testVersion)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestWithOmitInVerboseAndFields(t *testing.T) {
+	stubRuntime(t)
+
+	if got := version.Verbose(version.WithOmit(version.FieldBuiltBy)); strings.Contains(got, testUser) {
+		t.Errorf("Verbose(WithOmit(builtBy)) =\n%s\nwant no mention of %q", got, testUser)
+	}
+
+	for _, field := range version.Fields(version.WithOmit(version.FieldBuiltBy)) {
+		if field.Name == version.FieldBuiltBy {
+			t.Errorf("Fields(WithOmit(builtBy)) includes %v", field)
+		}
+	}
+}
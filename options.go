@@ -0,0 +1,303 @@
+package version
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The suffix appended to the commit when the binary was built from a modified working tree.
+const dirtySuffix = "-dirty"
+
+// Option tunes how the version details are derived and rendered.
+type Option func(*options)
+
+type options struct {
+	dateLayout  string
+	shortCommit int
+	format      string
+	env         bool
+	envPrefix   string
+	platform    bool
+	utc         bool
+	color       colorMode
+
+	dependencies bool
+
+	keepExeSuffix bool
+
+	omitCommitHeader bool
+
+	omit   map[string]bool
+	redact map[string]string
+
+	unknown        string
+	unknownVersion string
+
+	separator string
+
+	executablePath bool
+	hostname       bool
+	modulePath     bool
+
+	// The channel that abandons a slow lookup of the current user when closed, as set by DetailsContext.
+	userLookupDone <-chan struct{}
+
+	// Whether the values are being derived for the host binary, rather than for a Build constructed with New.
+	host bool
+}
+
+func newOptions(opts []Option) options {
+	o := options{
+		unknown:        unknownValue,
+		unknownVersion: defaultVersion,
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// WithDateLayout sets the layout, as accepted by 'time.Time.Format', used for the build date in place of RFC3339.
+// A build date derived from the modification time of the executable is formatted with the layout directly, while one
+// set with ldflags or the environment is reformatted if it parses as an RFC3339 timestamp, or else passed through
+// verbatim.
+func WithDateLayout(layout string) Option {
+	return func(o *options) {
+		o.dateLayout = layout
+	}
+}
+
+// WithUTC converts the build date to UTC before formatting it, so that identical binaries report the same build date
+// regardless of the local time zone of the machine they run on. This applies to a build date derived from the
+// modification time of the executable, and to one set with ldflags or the environment that parses as an RFC3339
+// timestamp with an explicit offset.
+func WithUTC() Option {
+	return func(o *options) {
+		o.utc = true
+	}
+}
+
+// WithShortCommit truncates the commit to its first n characters, preserving any '-dirty' suffix.
+// A value of n that is zero or less leaves the commit unchanged.
+func WithShortCommit(n int) Option {
+	return func(o *options) {
+		o.shortCommit = n
+	}
+}
+
+// WithFullCommit shows the full commit hash in the output of Details, which otherwise abbreviates it to
+// ShortCommitLength characters. Elsewhere the commit is shown in full unless WithShortCommit is given.
+func WithFullCommit() Option {
+	return func(o *options) {
+		o.shortCommit = 0
+	}
+}
+
+// WithFormat replaces the format used by Details.
+// The format is given to 'fmt.Sprintf' along with the executable, version, builtBy, commit, builtWith, and buildDate
+// values, in that order.
+func WithFormat(format string) Option {
+	return func(o *options) {
+		o.format = format
+	}
+}
+
+// WithPlatform appends the target operating system and architecture to the sentence returned by Details, in the form
+// '... at <buildDate> on <os>/<arch>.'. It has no effect when a format is given with WithFormat.
+func WithPlatform() Option {
+	return func(o *options) {
+		o.platform = true
+	}
+}
+
+// WithSeparator renders the output of Details as 'key=value' pairs joined by the given separator, such as '; ', in
+// place of the prose sentence, so that it can be split apart reliably. The keys are the Field* constants, in the order
+// returned by Fields, and include os and arch if WithPlatform is also given.
+// Any value containing the separator, or starting with a double quote, is quoted as a Go string literal, so that it can
+// be recovered with 'strconv.Unquote'. An empty separator keeps the prose sentence, and a format given with WithFormat
+// takes precedence over either.
+func WithSeparator(sep string) Option {
+	return func(o *options) {
+		o.separator = sep
+	}
+}
+
+// WithExeSuffix keeps the '.exe' suffix on the executable name derived from the runtime on Windows, which is otherwise
+// trimmed for consistency across platforms. An executable name set with ldflags is never altered.
+func WithExeSuffix() Option {
+	return func(o *options) {
+		o.keepExeSuffix = true
+	}
+}
+
+// WithUnknownValue replaces 'unknown' as the fallback for any field that could not be derived, with something such as
+// 'n/a', a word in another language, or an empty string. As a version such as 'v0.0.0-n/a' would no longer be valid,
+// the same value also replaces the fallback version of 'v0.0.0-unknown'.
+func WithUnknownValue(s string) Option {
+	return func(o *options) {
+		o.unknown = s
+		o.unknownVersion = s
+	}
+}
+
+// WithExecutablePath fills in the ExecutablePath field of the Info, so that the absolute path of the binary is included
+// in the output of JSON and Verbose. It is left out by default, as it differs between otherwise identical installs.
+func WithExecutablePath() Option {
+	return func(o *options) {
+		o.executablePath = true
+	}
+}
+
+// WithHostname fills in the Hostname field of the Info, so that the name of the host the binary is running on is
+// included in the output of JSON and Verbose, as when reporting to a central registry. The name is looked up afresh
+// each time, rather than being cached.
+func WithHostname() Option {
+	return func(o *options) {
+		o.hostname = true
+	}
+}
+
+// WithModulePath fills in the ModulePath field of the Info, so that the path of the main module is included in the
+// output of JSON and Verbose, telling apart the programs that embed a library reporting its version.
+func WithModulePath() Option {
+	return func(o *options) {
+		o.modulePath = true
+	}
+}
+
+// WithoutCommitHeader stops Middleware from adding the 'X-Commit' header to responses.
+func WithoutCommitHeader() Option {
+	return func(o *options) {
+		o.omitCommitHeader = true
+	}
+}
+
+// formatArgs returns the format and the arguments to go with it, with which to render the given Info as a sentence.
+// Without a format from WithFormat, the sentence is assembled from one clause per field, so that any fields dropped
+// with WithOmit leave no dangling words behind.
+func (o options) formatArgs(i Info) (string, []any) {
+	if o.format != "" {
+		i = o.blankOmitted(i)
+
+		return o.format, []any{i.Executable, i.Version, i.BuiltBy, i.Commit, i.BuiltWith, i.BuildDate}
+	}
+
+	if o.separator != "" {
+		return o.keyValueFormatArgs(i)
+	}
+
+	clauses := []struct {
+		field  string
+		format string
+		value  string
+	}{
+		{FieldExecutable, "%s", i.Executable},
+		{FieldVersion, "%s", i.Version},
+		{FieldBuiltBy, "built by %s", i.BuiltBy},
+		{FieldCommit, "from commit %s", i.Commit},
+		{FieldBuiltWith, "with %s", i.BuiltWith},
+		{FieldBuildDate, "at %s", i.BuildDate},
+	}
+
+	formats := make([]string, 0, len(clauses)+1)
+	args := make([]any, 0, len(clauses)+2)
+
+	for _, clause := range clauses {
+		if !o.omit[clause.field] {
+			formats = append(formats, clause.format)
+			args = append(args, clause.value)
+		}
+	}
+
+	if o.platform && !o.omit[FieldOS] && !o.omit[FieldArch] {
+		formats = append(formats, "on %s/%s")
+		args = append(args, i.OS, i.Arch)
+	}
+
+	return strings.Join(formats, " ") + ".", args
+}
+
+// keyValueFormatArgs returns the format and arguments with which to render the given Info as 'key=value' pairs joined
+// by the separator from WithSeparator.
+func (o options) keyValueFormatArgs(i Info) (string, []any) {
+	fields := o.withoutOmitted(i.fields(o))
+	formats := make([]string, 0, len(fields))
+	args := make([]any, 0, len(fields))
+
+	for _, field := range fields {
+		value := field.Value
+		if strings.Contains(value, o.separator) || strings.HasPrefix(value, `"`) {
+			value = strconv.Quote(value)
+		}
+
+		formats = append(formats, field.Name+"=%s")
+		args = append(args, value)
+	}
+
+	return strings.Join(formats, strings.ReplaceAll(o.separator, "%", "%%")), args
+}
+
+// apply returns a copy of the given Info with the field-level options applied.
+// The fallback for an unknown commit is never abbreviated, whatever it was replaced with by WithUnknownValue.
+func (o options) apply(i Info) Info {
+	if i.Commit != o.unknown {
+		i.Commit = shortCommit(i.Commit, o.shortCommit)
+	}
+
+	return o.redacted(i)
+}
+
+// formatTime formats the given time with the layout from WithDateLayout, or as RFC3339 by default, after converting it
+// to UTC if WithUTC was given.
+func (o options) formatTime(t time.Time) string {
+	if o.utc {
+		t = t.UTC()
+	}
+
+	if o.dateLayout == "" {
+		return t.Format(time.RFC3339)
+	}
+
+	return t.Format(o.dateLayout)
+}
+
+// shortCommit truncates the commit to its first n characters, preserving any '-dirty' suffix.
+// If n is zero or less, or not shorter than the commit hash, or the commit is not a hexadecimal hash at all, such as a
+// fallback value, the commit is returned unchanged.
+func shortCommit(commit string, n int) string {
+	hash := strings.TrimSuffix(commit, dirtySuffix)
+
+	if n <= 0 || n >= len(hash) || !isHex(hash) {
+		return commit
+	}
+
+	return hash[:n] + commit[len(hash):]
+}
+
+// formatDate reformats an RFC3339 timestamp as for formatTime.
+// If neither WithDateLayout nor WithUTC was given, or the timestamp cannot be parsed, the date is returned unchanged.
+func (o options) formatDate(date string) string {
+	if o.dateLayout == "" && !o.utc {
+		return date
+	}
+
+	t, err := time.Parse(time.RFC3339, date)
+	if err != nil {
+		return date
+	}
+
+	return o.formatTime(t)
+}
+
+func isHex(s string) bool {
+	for _, r := range s {
+		if (r < '0' || r > '9') && (r < 'a' || r > 'f') && (r < 'A' || r > 'F') {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,323 @@
+package version_test
+
+import (
+	"io/fs"
+	"os/user"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"go.jlucktay.dev/version"
+)
+
+//nolint:paralleltest // Overrides the package seams.
+func TestDetailsOptions(t *testing.T) {
+	stubRuntime(t)
+
+	testCases := map[string]struct {
+		opts []version.Option
+		want string
+	}{
+		"no options": {
+			want: "testapp v1.2.3 built by tester from commit 0123456 with go1.20.2 at 2006-01-02T15:04:05Z.",
+		},
+		"date layout": {
+			opts: []version.Option{version.WithDateLayout("2006-01-02")},
+			want: "testapp v1.2.3 built by tester from commit 0123456 with go1.20.2 at 2006-01-02.",
+		},
+		"short commit": {
+			opts: []version.Option{version.WithShortCommit(10)},
+			want: "testapp v1.2.3 built by tester from commit 0123456789 with go1.20.2 at 2006-01-02T15:04:05Z.",
+		},
+		"format": {
+			opts: []version.Option{version.WithFormat("%s@%s by %s (%s) %s %s")},
+			want: "testapp@v1.2.3 by tester (0123456) go1.20.2 2006-01-02T15:04:05Z",
+		},
+		"format with short commit": {
+			opts: []version.Option{version.WithFormat("%[1]s %[2]s %[4]s"), version.WithShortCommit(4)},
+			want: "testapp v1.2.3 0123",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(name, func(t *testing.T) {
+			if got := version.Details(testCase.opts...); got != testCase.want {
+				t.Errorf("Details() = %q, want %q", got, testCase.want)
+			}
+		})
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestWithPlatform(t *testing.T) {
+	stubRuntime(t)
+
+	want := "testapp v1.2.3 built by tester from commit 0123456 with go1.20.2 at 2006-01-02T15:04:05Z on " +
+		runtime.GOOS + "/" + runtime.GOARCH + "."
+
+	if got := version.Details(version.WithPlatform()); got != want {
+		t.Errorf("Details(WithPlatform()) = %q, want %q", got, want)
+	}
+
+	if got := version.Details(version.WithPlatform(), version.WithFormat("%[1]s")); got != testExecutable {
+		t.Errorf("Details(WithPlatform(), WithFormat()) = %q, want the format to take precedence", got)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestWithDateLayout(t *testing.T) {
+	testCases := map[string]struct {
+		buildDate string
+		want      string
+	}{
+		"derived":               {"", "02 Jan 06 15:04 UTC"},
+		"set as RFC3339":        {"2020-02-20T20:20:20Z", "20 Feb 20 20:20 UTC"},
+		"set in another layout": {"20/02/2020", "20/02/2020"},
+		"set with an offset":    {"2020-02-20T20:20:20+02:00", "20 Feb 20 20:20 +0200"},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(name, func(t *testing.T) {
+			stubRuntime(t)
+			version.SetBuildDate(testCase.buildDate)
+
+			if got := version.Current(version.WithDateLayout(time.RFC822)).BuildDate; got != testCase.want {
+				t.Errorf("Current(WithDateLayout()).BuildDate = %q, want %q", got, testCase.want)
+			}
+		})
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestWithUTC(t *testing.T) {
+	stubRuntime(t)
+
+	zone := time.FixedZone("UTC+2", 2*60*60)
+
+	version.Stub(t, version.OSStat, func(string) (fs.FileInfo, error) {
+		return fstest.MapFS{testExecutable: {ModTime: testModTime().In(zone)}}.Stat(testExecutable)
+	})
+
+	if got, want := version.BuildDate(), "2006-01-02T17:04:05+02:00"; got != want {
+		t.Errorf("BuildDate() = %q, want the local zone %q", got, want)
+	}
+
+	if got := version.Current(version.WithUTC()).BuildDate; got != testBuildDate {
+		t.Errorf("Current(WithUTC()).BuildDate = %q, want %q", got, testBuildDate)
+	}
+
+	version.SetBuildDate("2020-02-20T22:20:20+02:00")
+
+	if got, want := version.Current(version.WithUTC()).BuildDate, "2020-02-20T20:20:20Z"; got != want {
+		t.Errorf("Current(WithUTC()).BuildDate of an ldflag = %q, want %q", got, want)
+	}
+
+	version.SetBuildDate("yesterday")
+
+	if got := version.Current(version.WithUTC()).BuildDate; got != "yesterday" {
+		t.Errorf("Current(WithUTC()).BuildDate = %q, want an unparseable date passed through", got)
+	}
+}
+
+// stubNothingDerivable makes every runtime lookup behind the fallbacks fail until the end of the test.
+func stubNothingDerivable(t *testing.T) {
+	t.Helper()
+
+	stubRuntime(t)
+
+	version.Stub(t, version.OSExecutable, func() (string, error) {
+		return "", fs.ErrNotExist
+	})
+
+	version.Stub(t, version.OSStat, func(string) (fs.FileInfo, error) {
+		return nil, fs.ErrNotExist
+	})
+
+	version.Stub(t, version.UserCurrent, func() (*user.User, error) {
+		return nil, fs.ErrPermission
+	})
+
+	version.Stub(t, version.OSGetuid, func() int {
+		return -1
+	})
+
+	version.Stub(t, version.ReadBuildInfo, func() (*debug.BuildInfo, bool) {
+		return nil, false
+	})
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestWithUnknownValue(t *testing.T) {
+	for _, unknown := range []string{"n/a", "inconnu", ""} {
+		unknown := unknown
+
+		t.Run(unknown, func(t *testing.T) {
+			stubNothingDerivable(t)
+
+			got := version.Current(version.WithUnknownValue(unknown))
+
+			for name, value := range map[string]string{
+				version.FieldExecutable: got.Executable,
+				version.FieldVersion:    got.Version,
+				version.FieldBuiltBy:    got.BuiltBy,
+				version.FieldCommit:     got.Commit,
+				version.FieldBuiltWith:  got.BuiltWith,
+				version.FieldBuildDate:  got.BuildDate,
+			} {
+				if value != unknown {
+					t.Errorf("Current(WithUnknownValue(%q)) %s = %q, want %q", unknown, name, value, unknown)
+				}
+			}
+		})
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestWithUnknownValueLeavesKnownValues(t *testing.T) {
+	stubNothingDerivable(t)
+	version.SetVersion("v1.0.0")
+
+	got := version.Current(version.WithUnknownValue("n/a"))
+
+	if got.Version != "v1.0.0" || got.Commit != "n/a" {
+		t.Errorf("Current(WithUnknownValue(\"n/a\")) = %#v, want the explicit version kept", got)
+	}
+
+	want := "n/a v1.0.0 built by n/a from commit n/a with n/a at n/a."
+	if details := version.Details(version.WithUnknownValue("n/a")); details != want {
+		t.Errorf("Details(WithUnknownValue(\"n/a\")) = %q, want %q", details, want)
+	}
+
+	if got := version.Current(); got.Commit != "unknown" || got.Executable != "unknown" {
+		t.Errorf("Current() = %#v, want the default fallback back without the option", got)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestWithUnknownValueIsNotAbbreviated(t *testing.T) {
+	stubNothingDerivable(t)
+
+	const unknown = "not available"
+
+	want := "not available not available built by not available from commit not available with not available at " +
+		"not available."
+	if got := version.Details(version.WithUnknownValue(unknown)); got != want {
+		t.Errorf("Details(WithUnknownValue(%q)) = %q, want %q", unknown, got, want)
+	}
+
+	build := version.New(version.Config{Executable: "mylib", Version: "v1.0.0", Commit: unknown})
+	if got, want := build.Short(), "mylib v1.0.0 (not available)"; got != want {
+		t.Errorf("Short() = %q, want %q", got, want)
+	}
+
+	info := version.Info{Executable: "mylib", Version: "v1.0.0", Commit: unknown}
+	if got := info.String(); !strings.Contains(got, "from commit not available ") {
+		t.Errorf("String() = %q, want the whole commit", got)
+	}
+
+	var buf strings.Builder
+	if _, err := info.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := buf.String(); !strings.Contains(got, "from commit not available ") {
+		t.Errorf("WriteTo() wrote %q, want the whole commit", got)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestWithSeparator(t *testing.T) {
+	stubRuntime(t)
+
+	testCases := map[string]struct {
+		opts []version.Option
+		want string
+	}{
+		"semicolons": {
+			opts: []version.Option{version.WithSeparator("; ")},
+			want: "executable=testapp; version=v1.2.3; builtBy=tester; commit=0123456; builtWith=go1.20.2; " +
+				"buildDate=2006-01-02T15:04:05Z",
+		},
+		"percent signs are literal": {
+			opts: []version.Option{version.WithSeparator(" % "), version.WithOmit(version.FieldBuildDate)},
+			want: "executable=testapp % version=v1.2.3 % builtBy=tester % commit=0123456 % builtWith=go1.20.2",
+		},
+		"with the platform": {
+			opts: []version.Option{version.WithSeparator(" "), version.WithPlatform()},
+			want: "executable=testapp version=v1.2.3 builtBy=tester commit=0123456 builtWith=go1.20.2 " +
+				"buildDate=2006-01-02T15:04:05Z os=" + runtime.GOOS + " arch=" + runtime.GOARCH,
+		},
+		"empty keeps the sentence": {
+			opts: []version.Option{version.WithSeparator("")},
+			want: version.Details(),
+		},
+		"format takes precedence": {
+			opts: []version.Option{version.WithSeparator("; "), version.WithFormat("%[2]s")},
+			want: testVersion,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(name, func(t *testing.T) {
+			if got := version.Details(testCase.opts...); got != testCase.want {
+				t.Errorf("Details() =\n%q\nwant\n%q", got, testCase.want)
+			}
+		})
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestWithSeparatorQuotesValuesContainingIt(t *testing.T) {
+	stubRuntime(t)
+	version.SetBuiltBy("Doe, Jane")
+	version.SetExecutable(`"quoted"`)
+
+	got := version.Details(version.WithSeparator(", "), version.WithOmit(version.FieldCommit, version.FieldBuildDate))
+
+	if want := `executable="\"quoted\"", version=v1.2.3, builtBy="Doe, Jane", builtWith=go1.20.2`; got != want {
+		t.Errorf("Details(WithSeparator()) =\n%q\nwant\n%q", got, want)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestDetailsFullGolden(t *testing.T) {
+	stubRuntime(t)
+	version.SetBuiltBy("ci")
+
+	got := version.DetailsFull()
+
+	// The platform differs between the machines running the tests, so is replaced with a placeholder.
+	platform := " on " + runtime.GOOS + "/" + runtime.GOARCH + "."
+	if !strings.HasSuffix(got, platform) {
+		t.Fatalf("DetailsFull() = %q, want it to end with %q", got, platform)
+	}
+
+	assertGolden(t, "details_full", []byte(strings.TrimSuffix(got, platform)+" on <os>/<arch>.\n"))
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestDetailsFullExtendsDetails(t *testing.T) {
+	stubRuntime(t)
+
+	if got, want := version.DetailsFull(), version.Details(version.WithPlatform()); got != want {
+		t.Errorf("DetailsFull() = %q, want %q", got, want)
+	}
+
+	if got, want := version.DetailsFull(), strings.TrimSuffix(version.Details(), "."); !strings.HasPrefix(got, want) {
+		t.Errorf("DetailsFull() = %q, want it to extend %q", got, want)
+	}
+
+	// Options are passed through, and any that drop the platform win.
+	if got, want := version.DetailsFull(version.WithOmit(version.FieldArch)), version.Details(); got != want {
+		t.Errorf("DetailsFull(WithOmit(arch)) = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,27 @@
+// Package otelversion maps the version details of the currently executing binary onto OpenTelemetry resource
+// attributes.
+package otelversion
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+
+	"go.jlucktay.dev/version"
+)
+
+// CommitKey is the attribute key for the commit that the binary was built from, which has no semantic convention.
+const CommitKey = attribute.Key("build.commit")
+
+// ResourceAttributes returns attributes describing the caller, suitable for attaching to an OpenTelemetry resource:
+//   - 'service.version' from the version.
+//   - 'process.runtime.version' from the Go runtime version.
+//   - 'build.commit' from the commit.
+func ResourceAttributes() []attribute.KeyValue {
+	i := version.Current()
+
+	return []attribute.KeyValue{
+		semconv.ServiceVersion(i.Version),
+		semconv.ProcessRuntimeVersion(i.RuntimeGoVersion),
+		CommitKey.String(i.Commit),
+	}
+}
@@ -0,0 +1,32 @@
+package otelversion_test
+
+import (
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"go.jlucktay.dev/version"
+	"go.jlucktay.dev/version/otelversion"
+)
+
+func TestResourceAttributes(t *testing.T) {
+	t.Parallel()
+
+	info := version.Current()
+	want := map[attribute.Key]string{
+		"service.version":         info.Version,
+		"process.runtime.version": info.RuntimeGoVersion,
+		otelversion.CommitKey:     info.Commit,
+	}
+
+	got := otelversion.ResourceAttributes()
+	if len(got) != len(want) {
+		t.Fatalf("ResourceAttributes() = %v, want %d attributes", got, len(want))
+	}
+
+	for _, kv := range got {
+		if value, ok := want[kv.Key]; !ok || kv.Value.AsString() != value {
+			t.Errorf("attribute %s = %q, want %q", kv.Key, kv.Value.AsString(), value)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package version
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ErrMalformedDetails is returned by Parse when a string does not match the form returned by Details.
+var ErrMalformedDetails = errors.New("malformed version details")
+
+// Matches the default form of the string returned by Details.
+//
+//nolint:gochecknoglobals // Compiled once, and treated as a constant.
+var detailsPattern = regexp.MustCompile(`^(\S+) (\S+) built by (.+) from commit (\S+) with (\S+) at (.+)\.$`)
+
+// Parse is the inverse of Details without any options, taking a string of the form:
+//
+//	<executable> <version> built by <builtBy> from commit <commit> with <builtWith> at <buildDate>.
+//
+// and returning an Info with those six fields populated. Surrounding whitespace, such as a trailing newline, is
+// ignored. An error is returned if the string does not match the form.
+//
+// Parsing the output of Details with WithFullCommit gives back the same six fields as Current, and likewise an Info
+// survives a round trip through JSON unchanged, but the text form is lossy in a few edge cases:
+//   - Details and the String method of an Info abbreviate the commit, so without WithFullCommit only the short commit
+//     is given back.
+//   - The os, arch, runtimeGoVersion, cgoEnabled, and any other optional fields are not part of the string, and so
+//     are left empty.
+//   - The executable, version, commit, and builtWith values must not be empty or contain whitespace.
+//   - The builtBy value must not contain ' from commit ', and the buildDate value must not be empty.
+func Parse(s string) (Info, error) {
+	matches := detailsPattern.FindStringSubmatch(strings.TrimSpace(s))
+	if matches == nil {
+		return Info{}, fmt.Errorf("%w: %q", ErrMalformedDetails, s)
+	}
+
+	return Info{
+		Executable: matches[1],
+		Version:    matches[2],
+		BuiltBy:    matches[3],
+		Commit:     matches[4],
+		BuiltWith:  matches[5],
+		BuildDate:  matches[6],
+	}, nil
+}
@@ -0,0 +1,66 @@
+package version_test
+
+import (
+	"errors"
+	"testing"
+
+	"go.jlucktay.dev/version"
+)
+
+// sixFields returns the fields of the Info that are part of the string returned by Details.
+func sixFields(i version.Info) version.Info {
+	return version.Info{
+		Executable: i.Executable,
+		Version:    i.Version,
+		BuiltBy:    i.BuiltBy,
+		Commit:     i.Commit,
+		BuiltWith:  i.BuiltWith,
+		BuildDate:  i.BuildDate,
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestParseRoundTripsDetails(t *testing.T) {
+	stubRuntime(t)
+	version.SetBuiltBy("Jane Doe")
+
+	got, err := version.Parse(version.Details(version.WithFullCommit()) + "\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := sixFields(version.Current()); got != want {
+		t.Errorf("Parse(Details(WithFullCommit())) =\n%#v\nwant\n%#v", got, want)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestParseOfDefaultDetailsGivesShortCommit(t *testing.T) {
+	stubRuntime(t)
+
+	got, err := version.Parse(version.Details())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := testRevision[:version.ShortCommitLength]; got.Commit != want {
+		t.Errorf("Parse(Details()).Commit = %q, want %q", got.Commit, want)
+	}
+}
+
+func TestParseRejectsMalformedDetails(t *testing.T) {
+	t.Parallel()
+
+	for _, input := range []string{
+		"",
+		"myapp v1.2.3",
+		"myapp v1.2.3 built by me from commit abc with go1.20 at 2006-01-02T15:04:05Z",
+		"myapp v1.2.3 built by me from commit abc with go1.20 at .",
+		"my app v1.2.3 built by me from commit abc with go1.20 at 2006-01-02T15:04:05Z.",
+		"myapp v1.2.3 made by me from commit abc with go1.20 at 2006-01-02T15:04:05Z.",
+	} {
+		if _, err := version.Parse(input); !errors.Is(err, version.ErrMalformedDetails) {
+			t.Errorf("Parse(%q) error = %v, want %v", input, err, version.ErrMalformedDetails)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+// Package promversion provides a Prometheus collector exposing the version details of the currently executing binary
+// as the conventional 'build_info' gauge.
+package promversion
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"go.jlucktay.dev/version"
+)
+
+// MetricName is the name of the gauge emitted by the collector.
+const MetricName = "build_info"
+
+type collector struct {
+	desc *prometheus.Desc
+}
+
+// Collector returns a 'prometheus.Collector' that emits a single 'build_info' gauge with a constant value of 1,
+// labelled with the version, commit, and goversion from the Info describing the caller.
+func Collector() prometheus.Collector {
+	return collector{
+		desc: prometheus.NewDesc(
+			MetricName,
+			"A metric with a constant '1' value labelled by the version, commit, and Go toolchain of the build.",
+			[]string{"version", "commit", "goversion"},
+			nil,
+		),
+	}
+}
+
+// Describe implements 'prometheus.Collector'.
+func (c collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+// Collect implements 'prometheus.Collector'.
+func (c collector) Collect(ch chan<- prometheus.Metric) {
+	i := version.Current()
+
+	ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, 1, i.Version, i.Commit, i.BuiltWith)
+}
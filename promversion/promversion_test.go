@@ -0,0 +1,49 @@
+package promversion_test
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"go.jlucktay.dev/version"
+	"go.jlucktay.dev/version/promversion"
+)
+
+func TestCollector(t *testing.T) {
+	t.Parallel()
+
+	registry := prometheus.NewPedanticRegistry()
+	registry.MustRegister(promversion.Collector())
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(families) != 1 || families[0].GetName() != promversion.MetricName {
+		t.Fatalf("gathered %v, want a single %s family", families, promversion.MetricName)
+	}
+
+	metrics := families[0].GetMetric()
+	if len(metrics) != 1 {
+		t.Fatalf("gathered %d metrics, want 1", len(metrics))
+	}
+
+	if got := metrics[0].GetGauge().GetValue(); got != 1 {
+		t.Errorf("gauge value = %v, want 1", got)
+	}
+
+	info := version.Current()
+	want := map[string]string{"version": info.Version, "commit": info.Commit, "goversion": info.BuiltWith}
+	labels := metrics[0].GetLabel()
+
+	if len(labels) != len(want) {
+		t.Errorf("labels = %v, want %v", labels, want)
+	}
+
+	for _, label := range labels {
+		if want[label.GetName()] != label.GetValue() {
+			t.Errorf("label %s = %q, want %q", label.GetName(), label.GetValue(), want[label.GetName()])
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package version
+
+// The mask used by WithRedactBuiltBy.
+const defaultMask = "***"
+
+// WithRedact replaces the value of the named field, as given by a Field* constant, with the given mask wherever the
+// field appears in the output. Unlike WithOmit, the field itself is kept, so that anything parsing the output still
+// finds every key it expects. A field that is empty, such as cgoEnabled when unknown, is left empty.
+func WithRedact(field, mask string) Option {
+	return func(o *options) {
+		if o.redact == nil {
+			o.redact = make(map[string]string)
+		}
+
+		o.redact[field] = mask
+	}
+}
+
+// WithRedactBuiltBy masks the name of the user who built the binary as '***', for artifacts built under a personal
+// account that are then shipped externally.
+func WithRedactBuiltBy() Option {
+	return WithRedact(FieldBuiltBy, defaultMask)
+}
+
+// redacted returns a copy of the given Info with each field named by WithRedact replaced by its mask.
+func (o options) redacted(i Info) Info {
+	for name, value := range i.fieldRefs() {
+		if mask, ok := o.redact[name]; ok && *value != "" {
+			*value = mask
+		}
+	}
+
+	return i
+}
@@ -0,0 +1,69 @@
+package version_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"go.jlucktay.dev/version"
+)
+
+//nolint:paralleltest // Overrides the package seams.
+func TestWithRedactBuiltBy(t *testing.T) {
+	stubRuntime(t)
+
+	if got, want := version.Details(version.WithRedactBuiltBy()),
+		strings.Replace(version.Details(), " by "+testUser+" ", " by *** ", 1); got != want {
+		t.Errorf("Details(WithRedactBuiltBy()) = %q, want %q", got, want)
+	}
+
+	encoded, err := version.JSON(version.WithRedactBuiltBy())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got, want map[string]string
+	if err := json.Unmarshal(encoded, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	plain, err := version.JSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := json.Unmarshal(plain, &want); err != nil {
+		t.Fatal(err)
+	}
+
+	want[version.FieldBuiltBy] = "***"
+
+	for key, value := range want {
+		if got[key] != value {
+			t.Errorf("JSON(WithRedactBuiltBy()) %s = %q, want %q", key, got[key], value)
+		}
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestWithRedact(t *testing.T) {
+	stubRuntime(t)
+
+	got := version.Current(
+		version.WithRedact(version.FieldCommit, "REDACTED"),
+		version.WithRedact(version.FieldHostname, "x"),
+	)
+
+	if got.Commit != "REDACTED" {
+		t.Errorf("Current(WithRedact(commit)).Commit = %q, want %q", got.Commit, "REDACTED")
+	}
+
+	// An empty field stays empty, so that the mask does not invent a value.
+	if got.Hostname != "" {
+		t.Errorf("Current(WithRedact(hostname)).Hostname = %q, want it left empty", got.Hostname)
+	}
+
+	if got.Version != testVersion || got.BuiltBy != testUser {
+		t.Errorf("Current(WithRedact(commit)) = %#v, want the other fields untouched", got)
+	}
+}
@@ -0,0 +1,130 @@
+package version
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var (
+	// ErrAlreadyRegistered is returned by Register when a Build has already been registered under the given name.
+	ErrAlreadyRegistered = errors.New("version already registered")
+
+	// ErrInvalidRegistration is returned by Register when the name is empty or the Build is nil.
+	ErrInvalidRegistration = errors.New("invalid version registration")
+)
+
+// The Builds registered by name, such as those of the host binary and of any libraries it embeds.
+//
+//nolint:gochecknoglobals // Shared by every component of the binary, by design.
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]*Build{}
+)
+
+// Register makes the given Build available under the given name, so that a library can report its own version
+// alongside that of the binary embedding it, as in:
+//
+//	version.Register("mylib", version.New(version.Config{
+//		Executable: "mylib",
+//		Version:    "v0.4.0",
+//		Commit:     "89abcde",
+//		Isolated:   true,
+//	}))
+//
+// The host binary can register the shared Build from Default, so that its own details are listed too.
+// An error is returned if the name is empty, the Build is nil, or the name is already taken.
+func Register(name string, b *Build) error {
+	if name == "" || b == nil {
+		return fmt.Errorf("%w: a name and a Build are both required", ErrInvalidRegistration)
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if _, ok := registry[name]; ok {
+		return fmt.Errorf("%w: %q", ErrAlreadyRegistered, name)
+	}
+
+	registry[name] = b
+
+	return nil
+}
+
+// Get returns the Build registered under the given name, and reports whether there was one.
+func Get(name string) (*Build, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	b, ok := registry[name]
+
+	return b, ok
+}
+
+// AllDetails returns the string from Details for each registered Build on its own line, prefixed with the name it was
+// registered under and sorted by name, as in:
+//
+//	app: app v1.2.3 built by jlucktay from commit 0123456 with go1.20.2 at 2006-01-02T15:04:05Z.
+//	mylib: mylib v0.4.0 built by unknown from commit 89abcde with go1.20.2 at 2006-01-02T15:04:05Z.
+//
+// Any value left empty in the Config of a Build from New with Isolated set is reported as unknown, rather than
+// borrowed from the host binary, apart from the toolchain and build date that they share.
+// An empty string is returned if nothing has been registered.
+func AllDetails(opts ...Option) string {
+	names, builds := registered()
+	lines := make([]string, 0, len(names))
+
+	for index, name := range names {
+		lines = append(lines, name+": "+builds[index].Details(opts...))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// AllJSON returns a JSON object holding the JSON encoding of each registered Build, keyed by the name it was
+// registered under, with the keys sorted by name.
+func AllJSON(opts ...Option) ([]byte, error) {
+	names, builds := registered()
+	all := make(map[string]json.RawMessage, len(names))
+
+	for index, name := range names {
+		b, err := builds[index].JSON(opts...)
+		if err != nil {
+			return nil, fmt.Errorf("marshalling registered version %q: %w", name, err)
+		}
+
+		all[name] = b
+	}
+
+	b, err := json.Marshal(all)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling registered version info: %w", err)
+	}
+
+	return b, nil
+}
+
+// registered returns the names of the registered Builds in sorted order, along with the Builds in the same order.
+func registered() ([]string, []*Build) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	names := make([]string, 0, len(registry))
+
+	for name := range registry {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	builds := make([]*Build, 0, len(names))
+
+	for _, name := range names {
+		builds = append(builds, registry[name])
+	}
+
+	return names, builds
+}
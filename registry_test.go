@@ -0,0 +1,151 @@
+package version_test
+
+import (
+	"errors"
+	"testing"
+
+	"go.jlucktay.dev/version"
+)
+
+//nolint:paralleltest // Overrides the package seams.
+func TestRegisterAndGet(t *testing.T) {
+	stubRuntime(t)
+
+	lib := version.New(version.Config{Executable: "mylib", Version: "v0.4.0", Commit: "89abcde", Isolated: true})
+
+	if err := version.Register("app", version.Default()); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := version.Register("mylib", lib); err != nil {
+		t.Fatal(err)
+	}
+
+	gotApp, ok := version.Get("app")
+	if !ok || gotApp != version.Default() {
+		t.Fatalf("Get(\"app\") = %p, %t, want the Default Build", gotApp, ok)
+	}
+
+	gotLib, ok := version.Get("mylib")
+	if !ok || gotLib != lib {
+		t.Fatalf("Get(\"mylib\") = %p, %t, want the registered Build", gotLib, ok)
+	}
+
+	// Each reports its own version, without the other leaking in.
+	if got := gotApp.Info(); got.Executable != testExecutable || got.Version != testVersion {
+		t.Errorf("app Info() = %#v, want the host binary", got)
+	}
+
+	if got := gotLib.Info(); got.Executable != "mylib" || got.Version != "v0.4.0" || got.Commit != "89abcde" {
+		t.Errorf("mylib Info() = %#v, want the library", got)
+	}
+
+	if got := gotLib.Info(); got.BuiltBy != "unknown" || got.BuiltWith != testGoVersion {
+		t.Errorf("mylib Info() = %#v, want no builder but the shared toolchain", got)
+	}
+
+	if _, ok := version.Get("missing"); ok {
+		t.Error("Get(\"missing\") reported a Build")
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestRegisterErrors(t *testing.T) {
+	stubRuntime(t)
+
+	if err := version.Register("app", version.Default()); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := version.Register("app", version.New(version.Config{})); !errors.Is(err, version.ErrAlreadyRegistered) {
+		t.Errorf("Register() of a taken name error = %v, want %v", err, version.ErrAlreadyRegistered)
+	}
+
+	if err := version.Register("", version.Default()); !errors.Is(err, version.ErrInvalidRegistration) {
+		t.Errorf("Register() with an empty name error = %v, want %v", err, version.ErrInvalidRegistration)
+	}
+
+	if err := version.Register("lib", nil); !errors.Is(err, version.ErrInvalidRegistration) {
+		t.Errorf("Register() of a nil Build error = %v, want %v", err, version.ErrInvalidRegistration)
+	}
+
+	if got, _ := version.Get("app"); got != version.Default() {
+		t.Errorf("Get(\"app\") = %p, want the first registration kept", got)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestAllDetails(t *testing.T) {
+	stubRuntime(t)
+
+	if got := version.AllDetails(); got != "" {
+		t.Errorf("AllDetails() with nothing registered = %q, want an empty string", got)
+	}
+
+	// Registered out of order, to check that the output is sorted by name.
+	for name, build := range map[string]*version.Build{
+		"zlib":  version.New(version.Config{Executable: "zlib", Version: "v2.0.0", Commit: "fedcba9", BuiltBy: "ci"}),
+		"app":   version.Default(),
+		"mylib": version.New(version.Config{Executable: "mylib", Version: "v0.4.0", Commit: "89abcde", Isolated: true}),
+	} {
+		if err := version.Register(name, build); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := "app: testapp v1.2.3 built by tester from commit 0123456 with go1.20.2 at 2006-01-02T15:04:05Z.\n" +
+		"mylib: mylib v0.4.0 built by unknown from commit 89abcde with go1.20.2 at 2006-01-02T15:04:05Z.\n" +
+		"zlib: zlib v2.0.0 built by ci from commit fedcba9 with go1.20.2 at 2006-01-02T15:04:05Z."
+	if got := version.AllDetails(); got != want {
+		t.Errorf("AllDetails() =\n%s\nwant\n%s", got, want)
+	}
+
+	// Options apply to every line.
+	want = "app: v1.2.3\nmylib: v0.4.0\nzlib: v2.0.0"
+	if got := version.AllDetails(version.WithFormat("%[2]s")); got != want {
+		t.Errorf("AllDetails(WithFormat()) =\n%s\nwant\n%s", got, want)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestAllJSON(t *testing.T) {
+	stubRuntime(t)
+
+	for name, build := range map[string]*version.Build{
+		"mylib": version.New(version.Config{Executable: "mylib", Version: "v0.4.0"}),
+		"app":   version.Default(),
+	} {
+		if err := version.Register(name, build); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	opts := []version.Option{version.WithOmit(version.FieldOS, version.FieldArch, version.FieldRuntimeGoVersion)}
+
+	got, err := version.AllJSON(opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	app, err := version.Default().JSON(opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lib, _ := version.Get("mylib")
+
+	mylib, err := lib.JSON(opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := `{"app":` + string(app) + `,"mylib":` + string(mylib) + `}`; string(got) != want {
+		t.Errorf("AllJSON() =\n%s\nwant\n%s", got, want)
+	}
+
+	version.Reset()
+
+	if got, err := version.AllJSON(); err != nil || string(got) != "{}" {
+		t.Errorf("AllJSON() with nothing registered = %s, %v, want {}", got, err)
+	}
+}
@@ -0,0 +1,38 @@
+package version
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// Render executes the given 'text/template' against the Info describing the caller, as in:
+//
+//	{{.Executable}} {{.Version}} @ {{.Commit}}
+//
+// Alongside the usual template functions, the following helpers are available:
+//   - short: abbreviates a commit hash to ShortCommitLength characters, preserving any '-dirty' suffix.
+//   - date: reformats an RFC3339 timestamp with the given layout, as in '{{date "2006-01-02" .BuildDate}}'.
+//
+// Any error from parsing or executing the template is returned.
+func Render(tmpl string) (string, error) {
+	t, err := template.New("version").Funcs(template.FuncMap{
+		"short": func(commit string) string {
+			return shortCommit(commit, ShortCommitLength)
+		},
+		"date": func(layout, date string) string {
+			return options{dateLayout: layout}.formatDate(date)
+		},
+	}).Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("parsing version template: %w", err)
+	}
+
+	var sb strings.Builder
+
+	if err = t.Execute(&sb, Current()); err != nil {
+		return "", fmt.Errorf("executing version template: %w", err)
+	}
+
+	return sb.String(), nil
+}
@@ -0,0 +1,45 @@
+package version_test
+
+import (
+	"testing"
+
+	"go.jlucktay.dev/version"
+)
+
+//nolint:paralleltest // Overrides the package seams.
+func TestRender(t *testing.T) {
+	stubRuntime(t)
+
+	testCases := map[string]string{
+		"{{.Executable}} {{.Version}} @ {{.Commit}}": "testapp v1.2.3 @ " + testRevision,
+		"{{short .Commit}}":                          "0123456",
+		`{{date "2006-01-02" .BuildDate}}`:           "2006-01-02",
+		`{{date "2006" "not a date"}}`:               "not a date",
+		"{{.BuiltBy | printf \"%q\"}}":               `"tester"`,
+		"plain text":                                 "plain text",
+	}
+
+	for tmpl, want := range testCases {
+		got, err := version.Render(tmpl)
+		if err != nil {
+			t.Errorf("Render(%q) error = %v", tmpl, err)
+
+			continue
+		}
+
+		if got != want {
+			t.Errorf("Render(%q) = %q, want %q", tmpl, got, want)
+		}
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestRenderErrors(t *testing.T) {
+	stubRuntime(t)
+
+	for _, tmpl := range []string{"{{.Executable", "{{.NoSuchField}}", "{{nosuchfunc .Commit}}"} {
+		if got, err := version.Render(tmpl); err == nil {
+			t.Errorf("Render(%q) = %q, want an error", tmpl, got)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package version_test
+
+import (
+	"encoding/json"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/quick"
+	"time"
+
+	"go.jlucktay.dev/version"
+)
+
+// explicitValues is a set of values to give to the setters, within the limits documented on Parse.
+type explicitValues struct {
+	Executable, Version, BuiltBy, Commit, BuiltWith, BuildDate string
+}
+
+// Generate implements 'quick.Generator', padding some of the values with whitespace to check that it is trimmed.
+func (explicitValues) Generate(rnd *rand.Rand, _ int) reflect.Value {
+	const (
+		letters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_.+/:@"
+		hex     = "0123456789abcdef"
+		maxLen  = 12
+		maxDate = 1 << 32
+	)
+
+	token := func(alphabet string, length int) string {
+		var sb strings.Builder
+
+		for index := 0; index < length; index++ {
+			sb.WriteByte(alphabet[rnd.Intn(len(alphabet))])
+		}
+
+		return sb.String()
+	}
+
+	word := func() string {
+		return token(letters, 1+rnd.Intn(maxLen))
+	}
+
+	pad := func(s string) string {
+		return strings.Repeat(" ", rnd.Intn(2)) + s + strings.Repeat("\t", rnd.Intn(2))
+	}
+
+	commit := token(hex, 40) //nolint:gomnd // The length of a SHA-1 hash.
+	if rnd.Intn(2) == 0 {
+		commit += "-dirty"
+	}
+
+	builtBy := word()
+	for words := rnd.Intn(3); words > 0; words-- { //nolint:gomnd // Up to three words.
+		builtBy += " " + word()
+	}
+
+	return reflect.ValueOf(explicitValues{
+		Executable: pad(word()),
+		Version:    pad("v" + word()),
+		BuiltBy:    pad(builtBy),
+		Commit:     pad(commit),
+		BuiltWith:  pad("go" + word()),
+		BuildDate:  pad(time.Unix(rnd.Int63n(maxDate), 0).UTC().Format(time.RFC3339)),
+	})
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestRoundTripsAreLossless(t *testing.T) {
+	stubRuntime(t)
+
+	property := func(values explicitValues) bool {
+		version.Reset()
+		version.SetExecutable(values.Executable)
+		version.SetVersion(values.Version)
+		version.SetBuiltBy(values.BuiltBy)
+		version.SetCommit(values.Commit)
+		version.SetBuiltWith(values.BuiltWith)
+		version.SetBuildDate(values.BuildDate)
+
+		current := version.Current()
+
+		if current.Executable != strings.TrimSpace(values.Executable) ||
+			current.BuiltBy != strings.TrimSpace(values.BuiltBy) {
+			t.Errorf("Current() = %#v, want the trimmed values of %#v", current, values)
+
+			return false
+		}
+
+		parsed, err := version.Parse(version.Details(version.WithFullCommit()))
+		if err != nil || parsed != sixFields(current) {
+			t.Errorf("Parse(Details(WithFullCommit())) = %#v, %v, want %#v", parsed, err, sixFields(current))
+
+			return false
+		}
+
+		encoded, err := version.JSON()
+		if err != nil {
+			t.Error(err)
+
+			return false
+		}
+
+		var decoded version.Info
+		if err := json.Unmarshal(encoded, &decoded); err != nil || decoded != current {
+			t.Errorf("decoded JSON() = %#v, %v, want %#v", decoded, err, current)
+
+			return false
+		}
+
+		return true
+	}
+
+	config := &quick.Config{MaxCount: 200, Rand: rand.New(rand.NewSource(1))} //nolint:gosec,gomnd // Reproducible.
+	if err := quick.Check(property, config); err != nil {
+		t.Error(err)
+	}
+}
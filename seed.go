@@ -0,0 +1,72 @@
+package version
+
+import "sync"
+
+// Guards the symbols that can be set with ldflags, as they can also be overridden at runtime.
+//
+//nolint:gochecknoglobals // Guards the ldflag symbols, which are themselves global.
+var seedMu sync.RWMutex
+
+// seed returns an Info populated with whatever was set with ldflags or overridden with one of the setters, and any
+// version loaded with LoadVersionFrom.
+func seed() Info {
+	seedMu.RLock()
+
+	i := Info{
+		Executable: executable,
+		Version:    version,
+		BuiltBy:    builtBy,
+		Commit:     commit,
+		BuiltWith:  builtWith,
+		BuildDate:  buildDate,
+	}
+
+	seedMu.RUnlock()
+
+	if i.Version == "" {
+		i.Version = getLoadedVersion()
+	}
+
+	return i
+}
+
+// set overrides one of the ldflag symbols while holding the lock.
+func set(symbol *string, value string) {
+	seedMu.Lock()
+	*symbol = value
+	seedMu.Unlock()
+
+	invalidate()
+}
+
+// SetExecutable overrides any executable name set with ldflags.
+// Like the other setters, it is intended to be called once at startup, for builds that assemble their version details
+// at runtime rather than with ldflags. Setting an empty string restores the usual fallbacks.
+func SetExecutable(value string) {
+	set(&executable, value)
+}
+
+// SetVersion overrides any version set with ldflags.
+func SetVersion(value string) {
+	set(&version, value)
+}
+
+// SetBuiltBy overrides any builtBy value set with ldflags.
+func SetBuiltBy(value string) {
+	set(&builtBy, value)
+}
+
+// SetCommit overrides any commit set with ldflags.
+func SetCommit(value string) {
+	set(&commit, value)
+}
+
+// SetBuiltWith overrides any builtWith value set with ldflags.
+func SetBuiltWith(value string) {
+	set(&builtWith, value)
+}
+
+// SetBuildDate overrides any build date set with ldflags.
+func SetBuildDate(value string) {
+	set(&buildDate, value)
+}
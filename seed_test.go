@@ -0,0 +1,77 @@
+package version_test
+
+import (
+	"sync"
+	"testing"
+
+	"go.jlucktay.dev/version"
+)
+
+//nolint:paralleltest // Overrides the package seams.
+func TestSetters(t *testing.T) {
+	testCases := map[string]struct {
+		set      func(string)
+		get      func() string
+		fallback string
+	}{
+		"executable": {version.SetExecutable, version.Executable, testExecutable},
+		"version":    {version.SetVersion, version.Version, testVersion},
+		"builtBy":    {version.SetBuiltBy, version.BuiltBy, testUser},
+		"commit":     {version.SetCommit, version.Commit, testRevision},
+		"builtWith":  {version.SetBuiltWith, version.BuiltWith, testGoVersion},
+		"buildDate":  {version.SetBuildDate, version.BuildDate, testBuildDate},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(name, func(t *testing.T) {
+			stubRuntime(t)
+
+			if got := testCase.get(); got != testCase.fallback {
+				t.Errorf("before setting = %q, want the fallback %q", got, testCase.fallback)
+			}
+
+			testCase.set("injected")
+
+			if got := testCase.get(); got != "injected" {
+				t.Errorf("after setting = %q, want %q", got, "injected")
+			}
+
+			testCase.set("")
+
+			if got := testCase.get(); got != testCase.fallback {
+				t.Errorf("after setting an empty string = %q, want the fallback %q", got, testCase.fallback)
+			}
+		})
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestSettersConcurrentWithReaders(t *testing.T) {
+	stubRuntime(t)
+
+	var wg sync.WaitGroup
+
+	for index := 0; index < 10; index++ {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+
+			version.SetVersion("v1.0.0")
+		}()
+
+		go func() {
+			defer wg.Done()
+
+			_ = version.Details()
+		}()
+	}
+
+	wg.Wait()
+
+	if got := version.Version(); got != "v1.0.0" {
+		t.Errorf("Version() = %q, want %q", got, "v1.0.0")
+	}
+}
@@ -0,0 +1,313 @@
+package version
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidSemVer is returned when a string cannot be parsed as a semantic version.
+	ErrInvalidSemVer = errors.New("invalid semantic version")
+
+	// ErrUnsupportedScanType is returned when scanning a database value of a type other than a string or bytes.
+	ErrUnsupportedScanType = errors.New("unsupported scan type")
+)
+
+// The number of dot-separated numeric components in the core of a semantic version.
+const semVerParts = 3
+
+// SemVer holds the components of a semantic version, as described at https://semver.org.
+type SemVer struct {
+	Major      int
+	Minor      int
+	Patch      int
+	Prerelease string
+	Metadata   string
+}
+
+// ParseSemVer parses the given string as a semantic version, tolerating a leading 'v' or 'V'.
+func ParseSemVer(s string) (SemVer, error) {
+	rest := trimVPrefix(s)
+
+	rest, metadata, hasMetadata := strings.Cut(rest, "+")
+	if hasMetadata && !validIdentifiers(metadata, false) {
+		return SemVer{}, fmt.Errorf("%w %q: malformed build metadata %q", ErrInvalidSemVer, s, metadata)
+	}
+
+	rest, prerelease, hasPrerelease := strings.Cut(rest, "-")
+	if hasPrerelease && !validIdentifiers(prerelease, true) {
+		return SemVer{}, fmt.Errorf("%w %q: malformed prerelease %q", ErrInvalidSemVer, s, prerelease)
+	}
+
+	parts := strings.Split(rest, ".")
+	if len(parts) != semVerParts {
+		return SemVer{}, fmt.Errorf("%w %q: want %d dot-separated numbers, got %d",
+			ErrInvalidSemVer, s, semVerParts, len(parts))
+	}
+
+	numbers := make([]int, semVerParts)
+
+	for index, part := range parts {
+		n, ok := parseNumericIdentifier(part)
+		if !ok {
+			return SemVer{}, fmt.Errorf("%w %q: malformed number %q", ErrInvalidSemVer, s, part)
+		}
+
+		numbers[index] = n
+	}
+
+	return SemVer{
+		Major:      numbers[0],
+		Minor:      numbers[1],
+		Patch:      numbers[2],
+		Prerelease: prerelease,
+		Metadata:   metadata,
+	}, nil
+}
+
+// CurrentSemVer parses the version of the currently executing binary, with the same fallback that Details uses.
+// The default 'v0.0.0-unknown' fallback parses to 0.0.0 with a prerelease of 'unknown'.
+func CurrentSemVer() (SemVer, error) {
+	return ParseSemVer(Version())
+}
+
+// IsPrerelease reports whether the version of the currently executing binary has a prerelease component, as in
+// 'v1.2.3-rc.1', for gating experimental features. The default 'v0.0.0-unknown' counts as a prerelease, as does a
+// version that cannot be parsed, since neither identifies a release.
+func IsPrerelease() bool {
+	sv, err := CurrentSemVer()
+
+	return err != nil || sv.Prerelease != ""
+}
+
+// BuildMetadata returns the build metadata of the version of the currently executing binary, being everything after
+// the '+', as in 'linux.amd64' from 'v1.2.3-rc.1+linux.amd64'. An empty string is returned if there is no metadata, or
+// if the version cannot be parsed. As the semver rules require, metadata has no bearing on the results of Compare.
+func BuildMetadata() string {
+	sv, err := CurrentSemVer()
+	if err != nil {
+		return ""
+	}
+
+	return sv.Metadata
+}
+
+// Compare returns -1, 0, or +1 depending on whether the first version has lower, equal, or higher precedence than the
+// second, following the rules at https://semver.org. Either version may have a leading 'v' or 'V'.
+//
+// If both versions are calendar versions as accepted by ParseCalVer, they are compared by year, month, and then
+// sequence number instead, whether or not they are also valid semantic versions. Comparing a calendar version with
+// a semantic version returns ErrMixedSchemes, and any other unparseable version returns an error.
+func Compare(a, b string) (int, error) {
+	// The calendar scheme is checked first, as a version such as '2024.3.1' is a valid semantic version too.
+	_, _, _, calErrA := ParseCalVer(a)
+	_, _, _, calErrB := ParseCalVer(b)
+
+	if calErrA == nil && calErrB == nil {
+		return compareCalVer(a, b)
+	}
+
+	svA, errA := ParseSemVer(a)
+	svB, errB := ParseSemVer(b)
+
+	switch {
+	case (calErrA == nil && errB == nil) || (errA == nil && calErrB == nil):
+		return 0, fmt.Errorf("%w: %q and %q", ErrMixedSchemes, a, b)
+	case errA == nil && errB == nil:
+		return svA.Compare(svB), nil
+	case errA != nil:
+		return 0, errA
+	default:
+		return 0, errB
+	}
+}
+
+// IsNewerThan reports whether the version of the currently executing binary has higher precedence than the other
+// version given.
+func IsNewerThan(other string) (bool, error) {
+	cmp, err := Compare(Version(), other)
+	if err != nil {
+		return false, err
+	}
+
+	return cmp > 0, nil
+}
+
+// Compare returns -1, 0, or +1 depending on whether sv has lower, equal, or higher precedence than other.
+// Build metadata does not affect precedence.
+func (sv SemVer) Compare(other SemVer) int {
+	if c := compareInts(sv.Major, other.Major); c != 0 {
+		return c
+	}
+
+	if c := compareInts(sv.Minor, other.Minor); c != 0 {
+		return c
+	}
+
+	if c := compareInts(sv.Patch, other.Patch); c != 0 {
+		return c
+	}
+
+	return comparePrerelease(sv.Prerelease, other.Prerelease)
+}
+
+// String returns the canonical form of the semantic version, with a leading 'v'.
+func (sv SemVer) String() string {
+	s := fmt.Sprintf("v%d.%d.%d", sv.Major, sv.Minor, sv.Patch)
+
+	if sv.Prerelease != "" {
+		s += "-" + sv.Prerelease
+	}
+
+	if sv.Metadata != "" {
+		s += "+" + sv.Metadata
+	}
+
+	return s
+}
+
+// MarshalText implements the 'encoding.TextMarshaler' interface, encoding the version in its canonical form.
+func (sv SemVer) MarshalText() ([]byte, error) {
+	return []byte(sv.String()), nil
+}
+
+// UnmarshalText implements the 'encoding.TextUnmarshaler' interface, as for ParseSemVer.
+func (sv *SemVer) UnmarshalText(text []byte) error {
+	parsed, err := ParseSemVer(string(text))
+	if err != nil {
+		return err
+	}
+
+	*sv = parsed
+
+	return nil
+}
+
+// Scan implements the 'sql.Scanner' interface, parsing a string or []byte column value as for ParseSemVer.
+// Any other type, including a NULL value, results in an error.
+func (sv *SemVer) Scan(src any) error {
+	switch v := src.(type) {
+	case string:
+		return sv.UnmarshalText([]byte(v))
+	case []byte:
+		return sv.UnmarshalText(v)
+	default:
+		return fmt.Errorf("%w %T into SemVer", ErrUnsupportedScanType, src)
+	}
+}
+
+// Value implements the 'driver.Valuer' interface, storing the version in its canonical form.
+func (sv SemVer) Value() (driver.Value, error) {
+	return sv.String(), nil
+}
+
+// comparePrerelease compares two prerelease strings by precedence, where a version without a prerelease has higher
+// precedence than one with.
+func comparePrerelease(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+
+	idsA, idsB := strings.Split(a, "."), strings.Split(b, ".")
+
+	for index := 0; index < len(idsA) && index < len(idsB); index++ {
+		if c := compareIdentifiers(idsA[index], idsB[index]); c != 0 {
+			return c
+		}
+	}
+
+	return compareInts(len(idsA), len(idsB))
+}
+
+// compareIdentifiers compares two prerelease identifiers.
+// Numeric identifiers are compared numerically and have lower precedence than alphanumeric ones, which are compared
+// lexically in ASCII sort order.
+func compareIdentifiers(a, b string) int {
+	numericA, numericB := isNumeric(a), isNumeric(b)
+
+	switch {
+	case numericA && numericB:
+		// Without leading zeroes, a longer number is always a larger one.
+		if c := compareInts(len(a), len(b)); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a, b)
+	case numericA:
+		return -1
+	case numericB:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// parseNumericIdentifier parses a non-negative decimal number without any leading zeroes, and reports whether it was
+// able to do so.
+func parseNumericIdentifier(s string) (int, bool) {
+	if s == "" || !isNumeric(s) || (len(s) > 1 && s[0] == '0') {
+		return 0, false
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, false
+	}
+
+	return n, true
+}
+
+// validIdentifiers reports whether s is a non-empty series of dot-separated identifiers made up of ASCII alphanumerics
+// and hyphens. If numericRules is set, purely numeric identifiers must not have leading zeroes, as with prereleases.
+func validIdentifiers(s string, numericRules bool) bool {
+	for _, identifier := range strings.Split(s, ".") {
+		if identifier == "" {
+			return false
+		}
+
+		for _, r := range identifier {
+			if !isIdentifierRune(r) {
+				return false
+			}
+		}
+
+		if numericRules && isNumeric(identifier) && len(identifier) > 1 && identifier[0] == '0' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isIdentifierRune(r rune) bool {
+	return r == '-' || (r >= '0' && r <= '9') || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
+
+func isNumeric(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
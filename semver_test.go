@@ -0,0 +1,354 @@
+package version_test
+
+import (
+	"encoding/json"
+	"errors"
+	"runtime/debug"
+	"testing"
+
+	"go.jlucktay.dev/version"
+)
+
+func TestParseSemVer(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]version.SemVer{
+		"v1.2.3":                   {Major: 1, Minor: 2, Patch: 3},
+		"1.2.3":                    {Major: 1, Minor: 2, Patch: 3},
+		"V10.20.30":                {Major: 10, Minor: 20, Patch: 30},
+		"v0.0.0-unknown":           {Prerelease: "unknown"},
+		"v1.2.3-rc.1":              {Major: 1, Minor: 2, Patch: 3, Prerelease: "rc.1"},
+		"v1.2.3-0.3.7":             {Major: 1, Minor: 2, Patch: 3, Prerelease: "0.3.7"},
+		"v1.2.3-x-y-z.--":          {Major: 1, Minor: 2, Patch: 3, Prerelease: "x-y-z.--"},
+		"v1.2.3+linux.amd64":       {Major: 1, Minor: 2, Patch: 3, Metadata: "linux.amd64"},
+		"v1.2.3-rc.1+build.001":    {Major: 1, Minor: 2, Patch: 3, Prerelease: "rc.1", Metadata: "build.001"},
+		"v1.0.0-beta+exp.sha.5114": {Major: 1, Prerelease: "beta", Metadata: "exp.sha.5114"},
+	}
+
+	for input, want := range testCases {
+		input, want := input, want
+
+		t.Run(input, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := version.ParseSemVer(input)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != want {
+				t.Errorf("ParseSemVer(%q) = %#v, want %#v", input, got, want)
+			}
+		})
+	}
+}
+
+func TestParseSemVerRejectsMalformedVersions(t *testing.T) {
+	t.Parallel()
+
+	for _, input := range []string{
+		"",
+		"v",
+		"v1",
+		"v1.2",
+		"v1.2.3.4",
+		"v01.2.3",
+		"v1.02.3",
+		"v1.2.-3",
+		"v1.2.x",
+		"v1.2.3-",
+		"v1.2.3-01",
+		"v1.2.3-rc..1",
+		"v1.2.3-rc_1",
+		"v1.2.3+",
+		"v1.2.3+meta..data",
+		"vv1.2.3",
+		"unknown",
+	} {
+		input := input
+
+		t.Run(input, func(t *testing.T) {
+			t.Parallel()
+
+			if got, err := version.ParseSemVer(input); !errors.Is(err, version.ErrInvalidSemVer) {
+				t.Errorf("ParseSemVer(%q) = %#v, %v, want %v", input, got, err, version.ErrInvalidSemVer)
+			}
+		})
+	}
+}
+
+func TestSemVerString(t *testing.T) {
+	t.Parallel()
+
+	sv := version.SemVer{Major: 1, Minor: 2, Patch: 3, Prerelease: "rc.1", Metadata: "build.5"}
+
+	if got, want := sv.String(), "v1.2.3-rc.1+build.5"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestCurrentSemVer(t *testing.T) {
+	stubRuntime(t)
+
+	got, err := version.CurrentSemVer()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := (version.SemVer{Major: 1, Minor: 2, Patch: 3}); got != want {
+		t.Errorf("CurrentSemVer() = %#v, want %#v", got, want)
+	}
+
+	version.SetVersion("not a version")
+
+	if _, err := version.CurrentSemVer(); !errors.Is(err, version.ErrInvalidSemVer) {
+		t.Errorf("CurrentSemVer() error = %v, want %v", err, version.ErrInvalidSemVer)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestCurrentSemVerOfFallback(t *testing.T) {
+	stubBuildInfo(t, func(bi *debug.BuildInfo) {
+		bi.Main.Version = "(devel)"
+	})
+
+	got, err := version.CurrentSemVer()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := (version.SemVer{Prerelease: "unknown"}); got != want {
+		t.Errorf("CurrentSemVer() = %#v, want %#v", got, want)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	t.Parallel()
+
+	// Each version has lower precedence than the one after it, following the example given at https://semver.org.
+	ordered := []string{
+		"v0.0.0-unknown",
+		"v1.0.0-alpha",
+		"v1.0.0-alpha.1",
+		"v1.0.0-alpha.beta",
+		"v1.0.0-beta",
+		"v1.0.0-beta.2",
+		"v1.0.0-beta.11",
+		"v1.0.0-rc.1",
+		"v1.0.0",
+		"v1.0.1",
+		"v1.1.0",
+		"v1.10.0",
+		"v2.0.0",
+	}
+
+	for low := range ordered {
+		for high := range ordered {
+			want := 0
+
+			switch {
+			case low < high:
+				want = -1
+			case low > high:
+				want = 1
+			}
+
+			got, err := version.Compare(ordered[low], ordered[high])
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != want {
+				t.Errorf("Compare(%q, %q) = %d, want %d", ordered[low], ordered[high], got, want)
+			}
+		}
+	}
+}
+
+func TestCompareIgnoresPrefixAndMetadata(t *testing.T) {
+	t.Parallel()
+
+	for _, pair := range [][2]string{
+		{"v1.2.3", "1.2.3"},
+		{"V1.2.3", "v1.2.3"},
+		{"v1.2.3+linux", "v1.2.3+darwin"},
+		{"v1.2.3-rc.1+a", "1.2.3-rc.1"},
+	} {
+		if got, err := version.Compare(pair[0], pair[1]); err != nil || got != 0 {
+			t.Errorf("Compare(%q, %q) = %d, %v, want 0", pair[0], pair[1], got, err)
+		}
+	}
+}
+
+func TestCompareRejectsMalformedVersions(t *testing.T) {
+	t.Parallel()
+
+	if _, err := version.Compare("v1.2.3", "nope"); !errors.Is(err, version.ErrInvalidSemVer) {
+		t.Errorf("Compare() error = %v, want %v", err, version.ErrInvalidSemVer)
+	}
+
+	if _, err := version.Compare("v1.2", "v1.2.3"); !errors.Is(err, version.ErrInvalidSemVer) {
+		t.Errorf("Compare() error = %v, want %v", err, version.ErrInvalidSemVer)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestIsNewerThan(t *testing.T) {
+	stubRuntime(t)
+
+	for other, want := range map[string]bool{
+		"v1.2.2":      true,
+		"v1.2.3-rc.1": true,
+		"v1.2.3":      false,
+		"v1.3.0":      false,
+	} {
+		if got, err := version.IsNewerThan(other); err != nil || got != want {
+			t.Errorf("IsNewerThan(%q) = %t, %v, want %t", other, got, err, want)
+		}
+	}
+
+	if _, err := version.IsNewerThan("latest"); err == nil {
+		t.Error("IsNewerThan() with a malformed version returned no error")
+	}
+}
+
+func TestSemVerTextRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	type document struct {
+		Version version.SemVer `json:"version"`
+	}
+
+	original := document{Version: version.SemVer{Major: 1, Minor: 2, Patch: 3, Prerelease: "rc.1", Metadata: "b.5"}}
+
+	encoded, err := json.Marshal(original)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := `{"version":"v1.2.3-rc.1+b.5"}`; string(encoded) != want {
+		t.Errorf("json.Marshal() = %s, want %s", encoded, want)
+	}
+
+	var decoded document
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	if decoded != original {
+		t.Errorf("json.Unmarshal() = %#v, want %#v", decoded, original)
+	}
+}
+
+func TestSemVerUnmarshalTextRejectsMalformedVersions(t *testing.T) {
+	t.Parallel()
+
+	sv := version.SemVer{Major: 7}
+
+	if err := sv.UnmarshalText([]byte("1.2")); !errors.Is(err, version.ErrInvalidSemVer) {
+		t.Errorf("UnmarshalText() = %v, want %v", err, version.ErrInvalidSemVer)
+	}
+
+	if sv != (version.SemVer{Major: 7}) {
+		t.Errorf("UnmarshalText() left %#v, want the receiver untouched on error", sv)
+	}
+}
+
+func TestSemVerScan(t *testing.T) {
+	t.Parallel()
+
+	want := version.SemVer{Major: 1, Minor: 2, Patch: 3, Prerelease: "beta"}
+
+	for _, src := range []any{"v1.2.3-beta", []byte("1.2.3-beta")} {
+		var sv version.SemVer
+		if err := sv.Scan(src); err != nil {
+			t.Errorf("Scan(%#v) error = %v", src, err)
+		}
+
+		if sv != want {
+			t.Errorf("Scan(%#v) = %#v, want %#v", src, sv, want)
+		}
+	}
+
+	var sv version.SemVer
+
+	for _, src := range []any{nil, 123, 1.5} {
+		if err := sv.Scan(src); !errors.Is(err, version.ErrUnsupportedScanType) {
+			t.Errorf("Scan(%#v) error = %v, want %v", src, err, version.ErrUnsupportedScanType)
+		}
+	}
+
+	if err := sv.Scan("nope"); !errors.Is(err, version.ErrInvalidSemVer) {
+		t.Errorf("Scan(\"nope\") error = %v, want %v", err, version.ErrInvalidSemVer)
+	}
+}
+
+func TestSemVerValue(t *testing.T) {
+	t.Parallel()
+
+	value, err := version.SemVer{Major: 1, Minor: 2, Patch: 3, Metadata: "x"}.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if value != "v1.2.3+x" {
+		t.Errorf("Value() = %#v, want %#v", value, "v1.2.3+x")
+	}
+
+	// The stored value scans back to the same version.
+	var sv version.SemVer
+	if err := sv.Scan(value); err != nil || sv != (version.SemVer{Major: 1, Minor: 2, Patch: 3, Metadata: "x"}) {
+		t.Errorf("Scan(Value()) = %#v, %v, want the original version", sv, err)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestIsPrerelease(t *testing.T) {
+	testCases := map[string]bool{
+		"v1.2.3":         false,
+		"v1.2.3+build.1": false,
+		"v1.2.3-rc.1":    true,
+		"v1.2.3-0":       true,
+		"v0.0.0-unknown": true,
+		"garbage":        true,
+	}
+
+	for stamped, want := range testCases {
+		stamped, want := stamped, want
+
+		t.Run(stamped, func(t *testing.T) {
+			stubRuntime(t)
+			version.SetVersion(stamped)
+
+			if got := version.IsPrerelease(); got != want {
+				t.Errorf("IsPrerelease() of %q = %t, want %t", stamped, got, want)
+			}
+		})
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestBuildMetadata(t *testing.T) {
+	testCases := map[string]string{
+		"v1.2.3":                  "",
+		"v1.2.3-rc.1":             "",
+		"v1.2.3+linux.amd64":      "linux.amd64",
+		"v1.2.3-rc.1+linux.amd64": "linux.amd64",
+		"v1.2.3+exp.sha.5114f85":  "exp.sha.5114f85",
+		"garbage+meta":            "",
+	}
+
+	for stamped, want := range testCases {
+		stamped, want := stamped, want
+
+		t.Run(stamped, func(t *testing.T) {
+			stubRuntime(t)
+			version.SetVersion(stamped)
+
+			if got := version.BuildMetadata(); got != want {
+				t.Errorf("BuildMetadata() of %q = %q, want %q", stamped, got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+//go:build go1.21
+
+package version
+
+import "log/slog"
+
+// LogValue implements the 'slog.LogValuer' interface, so that an Info is logged as a group of attributes keyed the
+// same way as its JSON form.
+func (i Info) LogValue() slog.Value {
+	fields := i.allFields()
+	attrs := make([]slog.Attr, 0, len(fields))
+
+	for _, field := range fields {
+		if field.Value != "" || !omitsEmpty(field.Name) {
+			attrs = append(attrs, slog.String(field.Name, field.Value))
+		}
+	}
+
+	return slog.GroupValue(attrs...)
+}
@@ -0,0 +1,69 @@
+//go:build go1.21
+
+package version_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"go.jlucktay.dev/version"
+)
+
+func TestInfoLogValue(t *testing.T) {
+	t.Parallel()
+
+	info := version.Info{
+		Executable: "app",
+		Version:    "v1.0.0",
+		BuiltBy:    "someone",
+		Commit:     "abcdef0",
+		BuiltWith:  "go1.21.0",
+		BuildDate:  "2006-01-02T15:04:05Z",
+		OS:         "linux",
+		Arch:       "amd64",
+	}
+
+	var buf bytes.Buffer
+
+	slog.New(slog.NewJSONHandler(&buf, nil)).Info("starting", "build", info)
+
+	var logged struct {
+		Build map[string]string `json:"build"`
+	}
+
+	if err := json.Unmarshal(buf.Bytes(), &logged); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{
+		"executable":       "app",
+		"version":          "v1.0.0",
+		"builtBy":          "someone",
+		"commit":           "abcdef0",
+		"builtWith":        "go1.21.0",
+		"buildDate":        "2006-01-02T15:04:05Z",
+		"os":               "linux",
+		"arch":             "amd64",
+		"runtimeGoVersion": "",
+	}
+
+	if len(logged.Build) != len(want) {
+		t.Errorf("logged %d attributes %v, want %d", len(logged.Build), logged.Build, len(want))
+	}
+
+	for key, value := range want {
+		if got, ok := logged.Build[key]; !ok || got != value {
+			t.Errorf("logged %s = %q, want %q", key, got, value)
+		}
+	}
+}
+
+func TestInfoLogValueIsGroup(t *testing.T) {
+	t.Parallel()
+
+	if kind := (version.Info{}).LogValue().Kind(); kind != slog.KindGroup {
+		t.Errorf("LogValue().Kind() = %v, want %v", kind, slog.KindGroup)
+	}
+}
@@ -0,0 +1,21 @@
+package version
+
+// Snapshot pairs an Info with the modules compiled into the binary, for transports such as 'encoding/gob' that can
+// only carry fields and not the results of functions.
+//
+// Info is comparable and holds only exported string fields, so it can be gob-encoded on its own. Snapshot holds a
+// slice, so it must be compared field by field instead, bearing in mind that gob decodes an empty list of
+// dependencies as nil.
+type Snapshot struct {
+	Info         Info     `json:"info"`
+	Dependencies []Module `json:"dependencies"`
+}
+
+// CurrentSnapshot returns a Snapshot describing the caller, with the Info as from Current and the modules as from
+// Dependencies.
+func CurrentSnapshot(opts ...Option) Snapshot {
+	return Snapshot{
+		Info:         Current(opts...),
+		Dependencies: Dependencies(),
+	}
+}
@@ -0,0 +1,80 @@
+package version_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"reflect"
+	"testing"
+
+	"go.jlucktay.dev/version"
+)
+
+func TestInfoGobRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	original := version.Info{
+		Executable:       "myapp",
+		Version:          "v1.2.3",
+		BuiltBy:          "jlucktay",
+		Commit:           "0123456789abcdef0123456789abcdef01234567",
+		BuiltWith:        "go1.20.2",
+		BuildDate:        "2006-01-02T15:04:05Z",
+		OS:               "linux",
+		Arch:             "amd64",
+		RuntimeGoVersion: "go1.20.3",
+		CGOEnabled:       "true",
+		ExecutablePath:   "/opt/myapp/bin/myapp",
+		Hostname:         "buildhost",
+		ModulePath:       "example.com/myapp",
+	}
+
+	// Every field is set, so that the test fails if one is added without being filled in here.
+	fields := reflect.ValueOf(original)
+	for index := 0; index < fields.NumField(); index++ {
+		if fields.Field(index).IsZero() {
+			t.Fatalf("Info.%s is not set", fields.Type().Field(index).Name)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(original); err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded version.Info
+	if err := gob.NewDecoder(&buf).Decode(&decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	if decoded != original {
+		t.Errorf("gob round trip =\n%#v\nwant\n%#v", decoded, original)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestSnapshotGobRoundTrip(t *testing.T) {
+	stubRuntime(t)
+
+	original := version.CurrentSnapshot()
+	if len(original.Dependencies) == 0 {
+		t.Fatal("CurrentSnapshot() has no dependencies to encode")
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(original); err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded version.Snapshot
+	if err := gob.NewDecoder(&buf).Decode(&decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(decoded, original) {
+		t.Errorf("gob round trip =\n%#v\nwant\n%#v", decoded, original)
+	}
+
+	if decoded.Dependencies[1].Replace == nil || decoded.Dependencies[1].Replace.Path != "example.com/new" {
+		t.Errorf("gob round trip lost the replacement of %v", decoded.Dependencies[1])
+	}
+}
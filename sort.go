@@ -0,0 +1,55 @@
+package version
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Versions is a list of semantic versions that sorts in ascending order of precedence, implementing 'sort.Interface'.
+// Each version may have a leading 'v' or 'V'. Any that cannot be parsed sort after all of those that can, in lexical
+// order among themselves.
+type Versions []string
+
+func (vs Versions) Len() int {
+	return len(vs)
+}
+
+func (vs Versions) Less(i, j int) bool {
+	svI, errI := ParseSemVer(vs[i])
+	svJ, errJ := ParseSemVer(vs[j])
+
+	switch {
+	case errI == nil && errJ == nil:
+		return svI.Compare(svJ) < 0
+	case errI == nil:
+		return true
+	case errJ == nil:
+		return false
+	default:
+		return vs[i] < vs[j]
+	}
+}
+
+func (vs Versions) Swap(i, j int) {
+	vs[i], vs[j] = vs[j], vs[i]
+}
+
+// Sort sorts the versions in place, keeping the original order of any with equal precedence.
+func (vs Versions) Sort() {
+	sort.Stable(vs)
+}
+
+// SortVersions sorts the given versions in place by precedence, as with Versions.
+// If any of the versions cannot be parsed, an error reporting the first of them is returned and the slice is left
+// unsorted.
+func SortVersions(versions []string) error {
+	for index, v := range versions {
+		if _, err := ParseSemVer(v); err != nil {
+			return fmt.Errorf("sorting versions: element %d: %w", index, err)
+		}
+	}
+
+	Versions(versions).Sort()
+
+	return nil
+}
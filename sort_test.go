@@ -0,0 +1,92 @@
+package version_test
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"go.jlucktay.dev/version"
+)
+
+func TestVersionsSort(t *testing.T) {
+	t.Parallel()
+
+	got := version.Versions{
+		"v1.10.0",
+		"not-a-version",
+		"1.2.3",
+		"v1.0.0-rc.1",
+		"v1.0.0",
+		"V1.0.0-alpha",
+		"also-bad",
+		"v1.0.0-alpha.1",
+		"v1.0.0-beta.11",
+		"v1.0.0-beta.2",
+		"v1.2.3+build",
+	}
+
+	got.Sort()
+
+	want := version.Versions{
+		"V1.0.0-alpha",
+		"v1.0.0-alpha.1",
+		"v1.0.0-beta.2",
+		"v1.0.0-beta.11",
+		"v1.0.0-rc.1",
+		"v1.0.0",
+		"1.2.3",
+		"v1.2.3+build",
+		"v1.10.0",
+		"also-bad",
+		"not-a-version",
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Sort() =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestVersionsImplementsSortInterface(t *testing.T) {
+	t.Parallel()
+
+	got := version.Versions{"v2.0.0", "v1.0.0", "v1.5.0"}
+	sort.Sort(sort.Reverse(got))
+
+	if want := (version.Versions{"v2.0.0", "v1.5.0", "v1.0.0"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("sort.Sort(sort.Reverse()) = %q, want %q", got, want)
+	}
+}
+
+func TestSortVersions(t *testing.T) {
+	t.Parallel()
+
+	got := []string{"v0.3.0", "v0.1.0-rc.2", "v0.1.0", "v0.1.0-rc.10"}
+	if err := version.SortVersions(got); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []string{"v0.1.0-rc.2", "v0.1.0-rc.10", "v0.1.0", "v0.3.0"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SortVersions() = %q, want %q", got, want)
+	}
+}
+
+func TestSortVersionsReportsFirstUnparseableElement(t *testing.T) {
+	t.Parallel()
+
+	got := []string{"v2.0.0", "bad", "v1.0.0", "worse"}
+
+	err := version.SortVersions(got)
+	if !errors.Is(err, version.ErrInvalidSemVer) {
+		t.Fatalf("SortVersions() error = %v, want %v", err, version.ErrInvalidSemVer)
+	}
+
+	if want := "sorting versions: element 1: "; !strings.HasPrefix(err.Error(), want) {
+		t.Errorf("SortVersions() error = %q, want it to start with %q", err, want)
+	}
+
+	if want := []string{"v2.0.0", "bad", "v1.0.0", "worse"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SortVersions() left %q, want the slice unsorted", got)
+	}
+}
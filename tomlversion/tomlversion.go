@@ -0,0 +1,58 @@
+// Package tomlversion renders the version details of the currently executing binary as TOML.
+package tomlversion
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/BurntSushi/toml"
+
+	"go.jlucktay.dev/version"
+)
+
+// document mirrors version.Info with the same keys as its JSON encoding, emitted in the order that the fields are
+// declared below.
+type document struct {
+	Executable       string `toml:"executable"`
+	Version          string `toml:"version"`
+	BuiltBy          string `toml:"builtBy"`
+	Commit           string `toml:"commit"`
+	BuiltWith        string `toml:"builtWith"`
+	BuildDate        string `toml:"buildDate"`
+	OS               string `toml:"os"`
+	Arch             string `toml:"arch"`
+	RuntimeGoVersion string `toml:"runtimeGoVersion"`
+	CGOEnabled       string `toml:"cgoEnabled,omitempty"`
+	ExecutablePath   string `toml:"executablePath,omitempty"`
+	Hostname         string `toml:"hostname,omitempty"`
+	ModulePath       string `toml:"modulePath,omitempty"`
+}
+
+// TOML returns the TOML encoding of the given Info as a flat table of string values.
+// The keys are the same as the JSON keys documented on version.Info, in the same order: 'executable', 'version',
+// 'builtBy', 'commit', 'builtWith', 'buildDate', 'os', 'arch', 'runtimeGoVersion', 'cgoEnabled' when known, and
+// 'executablePath', 'hostname', and 'modulePath' when asked for.
+func TOML(i version.Info) ([]byte, error) {
+	var buf bytes.Buffer
+
+	err := toml.NewEncoder(&buf).Encode(document{
+		Executable:       i.Executable,
+		Version:          i.Version,
+		BuiltBy:          i.BuiltBy,
+		Commit:           i.Commit,
+		BuiltWith:        i.BuiltWith,
+		BuildDate:        i.BuildDate,
+		OS:               i.OS,
+		Arch:             i.Arch,
+		RuntimeGoVersion: i.RuntimeGoVersion,
+		CGOEnabled:       i.CGOEnabled,
+		ExecutablePath:   i.ExecutablePath,
+		Hostname:         i.Hostname,
+		ModulePath:       i.ModulePath,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshalling version info as TOML: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,107 @@
+package tomlversion_test
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+
+	"go.jlucktay.dev/version"
+	"go.jlucktay.dev/version/tomlversion"
+)
+
+// Rewrites the golden files under testdata with the output of the tests, as in 'go test ./... -update'.
+//
+//nolint:gochecknoglobals // Flags are registered at package initialisation.
+var update = flag.Bool("update", false, "rewrite the golden files under testdata")
+
+// testInfo has values for every field, including some that need escaping in a TOML string.
+func testInfo() version.Info {
+	return version.Info{
+		Executable:       "myapp",
+		Version:          "v1.2.3",
+		BuiltBy:          `Jane "JD" Doe`,
+		Commit:           "0123456789abcdef0123456789abcdef01234567",
+		BuiltWith:        "go1.20.2",
+		BuildDate:        "2006-01-02T15:04:05Z",
+		OS:               "windows",
+		Arch:             "amd64",
+		RuntimeGoVersion: "go1.20.3",
+		CGOEnabled:       "false",
+		ExecutablePath:   `C:\Program Files\myapp\myapp.exe`,
+		Hostname:         "buildhost",
+		ModulePath:       "example.com/myapp",
+	}
+}
+
+func TestTOMLGolden(t *testing.T) {
+	t.Parallel()
+
+	got, err := tomlversion.TOML(testInfo())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join("testdata", "toml.golden")
+
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(path, got, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading golden file: %v", err)
+	}
+
+	if !bytes.Equal(got, want) {
+		t.Errorf("output does not match %s:\ngot:\n%s\nwant:\n%s", path, got, want)
+	}
+}
+
+func TestTOMLRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	encoded, err := tomlversion.TOML(testInfo())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded map[string]any
+	if _, err := toml.Decode(string(encoded), &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	for key, want := range map[string]string{
+		version.FieldBuiltBy:        `Jane "JD" Doe`,
+		version.FieldCGOEnabled:     "false",
+		version.FieldExecutablePath: `C:\Program Files\myapp\myapp.exe`,
+	} {
+		if decoded[key] != want {
+			t.Errorf("TOML() %s = %#v, want %q", key, decoded[key], want)
+		}
+	}
+}
+
+func TestTOMLLeavesOutEmptyOptionalFields(t *testing.T) {
+	t.Parallel()
+
+	got, err := tomlversion.TOML(version.Info{Executable: "myapp"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range []string{"cgoEnabled", "executablePath", "hostname", "modulePath"} {
+		if bytes.Contains(got, []byte(key+" =")) {
+			t.Errorf("TOML() =\n%s\nwant no %s key", got, key)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package version
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+var (
+	// ErrUnexpectedStatus is returned by HTTPSource when the server responds with a status other than '200 OK'.
+	ErrUnexpectedStatus = errors.New("unexpected response status")
+
+	// ErrNoLatestVersion is returned by HTTPSource when the response does not hold a version.
+	ErrNoLatestVersion = errors.New("no latest version in response")
+)
+
+// The most bytes of a response that HTTPSource will read, which is ample for a single release from GitHub's API.
+const maxLatestBytes = 1 << 20
+
+// UpdateSource is where CheckLatest finds the latest released version, such as a GitHub or GitLab project, or a
+// custom endpoint.
+type UpdateSource interface {
+	// LatestVersion returns the latest released version, respecting ctx for cancellation and deadlines.
+	LatestVersion(ctx context.Context) (string, error)
+}
+
+// HTTPSource is an UpdateSource that fetches the latest version from a URL.
+//
+// The response body may either be the version as plain text, or a JSON object holding it under a 'tag_name' key, as
+// returned for the latest release by GitHub's releases API.
+type HTTPSource struct {
+	// URL is the address of the latest version.
+	URL string
+
+	// Client is used to make the request, or http.DefaultClient if nil.
+	Client *http.Client
+}
+
+// CheckLatest gets the latest released version from the given source and reports whether it is newer than the
+// version of the currently executing binary, as decided by Compare. The source is bound by ctx, so a deadline on ctx
+// acts as a timeout. Any failure to get or compare the versions is returned as an error, along with the latest version
+// if it was found.
+func CheckLatest(ctx context.Context, src UpdateSource) (string, bool, error) {
+	latest, err := src.LatestVersion(ctx)
+	if err != nil {
+		return "", false, fmt.Errorf("getting latest version: %w", err)
+	}
+
+	cmp, err := Compare(latest, Version())
+	if err != nil {
+		return latest, false, fmt.Errorf("comparing latest version: %w", err)
+	}
+
+	return latest, cmp > 0, nil
+}
+
+// LatestVersion fetches the latest version from the URL of the HTTPSource, satisfying the UpdateSource interface.
+func (hs HTTPSource) LatestVersion(ctx context.Context) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, hs.URL, http.NoBody)
+	if err != nil {
+		return "", fmt.Errorf("creating latest version request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json, text/plain")
+
+	client := hs.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("fetching latest version: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%w from %s: %s", ErrUnexpectedStatus, hs.URL, resp.Status)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxLatestBytes))
+	if err != nil {
+		return "", fmt.Errorf("reading latest version: %w", err)
+	}
+
+	latest := strings.TrimSpace(string(body))
+
+	if strings.HasPrefix(latest, "{") {
+		var release struct {
+			TagName string `json:"tag_name"`
+		}
+
+		if err := json.Unmarshal(body, &release); err != nil {
+			return "", fmt.Errorf("decoding latest version: %w", err)
+		}
+
+		latest = strings.TrimSpace(release.TagName)
+	}
+
+	if latest == "" {
+		return "", fmt.Errorf("%w from %s", ErrNoLatestVersion, hs.URL)
+	}
+
+	return latest, nil
+}
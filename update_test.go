@@ -0,0 +1,209 @@
+package version_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.jlucktay.dev/version"
+)
+
+// serveLatest returns a test server that responds to every request with the given status, content type, and body.
+func serveLatest(t *testing.T, status int, contentType, body string) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("request method = %s, want %s", r.Method, http.MethodGet)
+		}
+
+		w.Header().Set("Content-Type", contentType)
+		w.WriteHeader(status)
+		fmt.Fprint(w, body)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestCheckLatestOverHTTP(t *testing.T) {
+	testCases := map[string]struct {
+		contentType, body string
+		latest            string
+		isNewer           bool
+	}{
+		"newer plain text":  {"text/plain", "v1.3.0\n", "v1.3.0", true},
+		"older plain text":  {"text/plain", "v1.2.2", "v1.2.2", false},
+		"same version":      {"text/plain", "1.2.3", "1.2.3", false},
+		"newer GitHub JSON": {"application/json", `{"tag_name":"v2.0.0","name":"Two"}`, "v2.0.0", true},
+		"older GitHub JSON": {"application/json", ` {"tag_name":" v1.0.0 "}`, "v1.0.0", false},
+		"newer prerelease":  {"text/plain", "v1.2.4-rc.1", "v1.2.4-rc.1", true},
+		"release after rc":  {"text/plain", "v1.2.3-rc.1", "v1.2.3-rc.1", false},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(name, func(t *testing.T) {
+			stubRuntime(t)
+
+			server := serveLatest(t, http.StatusOK, testCase.contentType, testCase.body)
+
+			latest, isNewer, err := version.CheckLatest(context.Background(), version.HTTPSource{URL: server.URL})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if latest != testCase.latest || isNewer != testCase.isNewer {
+				t.Errorf("CheckLatest() = %q, %t, want %q, %t", latest, isNewer, testCase.latest, testCase.isNewer)
+			}
+		})
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestCheckLatestOverHTTPErrors(t *testing.T) {
+	testCases := map[string]struct {
+		status            int
+		contentType, body string
+		want              error
+	}{
+		"not found":         {http.StatusNotFound, "text/plain", "v9.9.9", version.ErrUnexpectedStatus},
+		"empty body":        {http.StatusOK, "text/plain", "  \n", version.ErrNoLatestVersion},
+		"JSON without tag":  {http.StatusOK, "application/json", `{"name":"v9.9.9"}`, version.ErrNoLatestVersion},
+		"malformed version": {http.StatusOK, "text/plain", "latest", version.ErrInvalidSemVer},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(name, func(t *testing.T) {
+			stubRuntime(t)
+
+			server := serveLatest(t, testCase.status, testCase.contentType, testCase.body)
+
+			_, isNewer, err := version.CheckLatest(context.Background(), version.HTTPSource{URL: server.URL})
+			if !errors.Is(err, testCase.want) || isNewer {
+				t.Errorf("CheckLatest() = %t, %v, want false, %v", isNewer, err, testCase.want)
+			}
+		})
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestCheckLatestOverHTTPWithMalformedJSON(t *testing.T) {
+	stubRuntime(t)
+
+	server := serveLatest(t, http.StatusOK, "application/json", `{"tag_name":`)
+
+	var syntaxErr *json.SyntaxError
+
+	_, _, err := version.CheckLatest(context.Background(), version.HTTPSource{URL: server.URL})
+	if !errors.As(err, &syntaxErr) {
+		t.Errorf("CheckLatest() of malformed JSON error = %v, want a %T", err, syntaxErr)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestCheckLatestOverHTTPRespectsContext(t *testing.T) {
+	stubRuntime(t)
+
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	t.Cleanup(server.Close)
+	t.Cleanup(func() { close(release) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, _, err := version.CheckLatest(ctx, version.HTTPSource{URL: server.URL})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("CheckLatest() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestCheckLatestOverHTTPWithoutServer(t *testing.T) {
+	stubRuntime(t)
+
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	src := version.HTTPSource{URL: server.URL, Client: &http.Client{Timeout: time.Second}}
+
+	if _, _, err := version.CheckLatest(context.Background(), src); err == nil {
+		t.Error("CheckLatest() against a closed server error = nil, want an error")
+	}
+}
+
+// fakeSource is an UpdateSource that returns a fixed version or error, recording the context it was given.
+type fakeSource struct {
+	latest string
+	err    error
+	ctx    context.Context //nolint:containedctx // Recorded to check that it is passed through.
+}
+
+func (fake *fakeSource) LatestVersion(ctx context.Context) (string, error) {
+	fake.ctx = ctx
+
+	return fake.latest, fake.err
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestCheckLatestWithFakeSource(t *testing.T) {
+	stubRuntime(t)
+
+	type ctxKey struct{}
+
+	ctx := context.WithValue(context.Background(), ctxKey{}, "marker")
+	src := &fakeSource{latest: "v1.10.0"}
+
+	latest, isNewer, err := version.CheckLatest(ctx, src)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if latest != "v1.10.0" || !isNewer {
+		t.Errorf("CheckLatest() = %q, %t, want %q, true", latest, isNewer, "v1.10.0")
+	}
+
+	if src.ctx == nil || src.ctx.Value(ctxKey{}) != "marker" {
+		t.Error("CheckLatest() did not pass its context to the source")
+	}
+
+	version.SetVersion("v2.0.0")
+
+	if _, isNewer, err := version.CheckLatest(ctx, src); err != nil || isNewer {
+		t.Errorf("CheckLatest() from a newer binary = %t, %v, want false, nil", isNewer, err)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestCheckLatestWithFailingSource(t *testing.T) {
+	stubRuntime(t)
+
+	errOffline := errors.New("offline")
+
+	latest, isNewer, err := version.CheckLatest(context.Background(), &fakeSource{latest: "v9.0.0", err: errOffline})
+	if !errors.Is(err, errOffline) || latest != "" || isNewer {
+		t.Errorf("CheckLatest() = %q, %t, %v, want \"\", false, %v", latest, isNewer, err, errOffline)
+	}
+
+	// A version that cannot be compared is still returned, alongside the error.
+	latest, _, err = version.CheckLatest(context.Background(), &fakeSource{latest: "2024.03.1"})
+	if !errors.Is(err, version.ErrMixedSchemes) || latest != "2024.03.1" {
+		t.Errorf("CheckLatest() of a calendar version = %q, %v, want %q, %v",
+			latest, err, "2024.03.1", version.ErrMixedSchemes)
+	}
+}
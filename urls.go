@@ -0,0 +1,84 @@
+package version
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+var (
+	// ErrUnknownVersion is returned when a version is needed but only the default fallback is available.
+	ErrUnknownVersion = errors.New("version is unknown")
+
+	// ErrUnknownCommit is returned when a commit is needed but could not be derived.
+	ErrUnknownCommit = errors.New("commit is unknown")
+
+	// ErrInvalidRepo is returned when a repository cannot be turned into a URL.
+	ErrInvalidRepo = errors.New("invalid repository")
+)
+
+// The host assumed for a repository given without one.
+const defaultRepoHost = "github.com"
+
+// ReleaseURL returns a link to the GitHub release for the version of the currently executing binary, such as
+// 'https://github.com/owner/name/releases/tag/v1.2.3'. The repository may be given as 'github.com/owner/name', with or
+// without a leading 'https://', or as a bare 'owner/name'.
+// An error is returned if the repository is malformed, or if the version is unknown, as there is no tag to link to.
+func ReleaseURL(repo string) (string, error) {
+	base, err := repoURL(repo)
+	if err != nil {
+		return "", err
+	}
+
+	v := Version()
+	if v == defaultVersion {
+		return "", ErrUnknownVersion
+	}
+
+	return base + "/releases/tag/" + url.PathEscape(v), nil
+}
+
+// CommitURL returns a link to the commit that the currently executing binary was built from, such as
+// 'https://github.com/owner/name/commit/0123456789abcdef0123456789abcdef01234567'. The repository may be given in any
+// of the forms accepted by ReleaseURL.
+// Any '-dirty' suffix is stripped from the commit, so the link points at the commit that the modified working tree was
+// based on. An error is returned if the repository is malformed, or if the commit is unknown.
+func CommitURL(repo string) (string, error) {
+	base, err := repoURL(repo)
+	if err != nil {
+		return "", err
+	}
+
+	c := strings.TrimSuffix(Commit(), dirtySuffix)
+	if c == unknownValue || c == "" {
+		return "", ErrUnknownCommit
+	}
+
+	return base + "/commit/" + url.PathEscape(c), nil
+}
+
+// repoURL normalises a repository given as 'host/owner/name', with or without a scheme, or as 'owner/name' on GitHub,
+// into an HTTPS URL with no trailing slash.
+func repoURL(repo string) (string, error) {
+	trimmed := strings.TrimPrefix(strings.TrimPrefix(repo, "https://"), "http://")
+	trimmed = strings.TrimSuffix(strings.TrimSuffix(trimmed, "/"), ".git")
+
+	parts := strings.Split(trimmed, "/")
+
+	switch len(parts) {
+	case 2: //nolint:gomnd // Owner and name.
+		parts = append([]string{defaultRepoHost}, parts...)
+	case 3: //nolint:gomnd // Host, owner, and name.
+	default:
+		return "", fmt.Errorf("%w %q: want 'owner/name' or 'host/owner/name'", ErrInvalidRepo, repo)
+	}
+
+	for _, part := range parts {
+		if part == "" {
+			return "", fmt.Errorf("%w %q: empty path segment", ErrInvalidRepo, repo)
+		}
+	}
+
+	return "https://" + strings.Join(parts, "/"), nil
+}
@@ -0,0 +1,117 @@
+package version_test
+
+import (
+	"errors"
+	"runtime/debug"
+	"testing"
+
+	"go.jlucktay.dev/version"
+)
+
+//nolint:paralleltest // Overrides the package seams.
+func TestReleaseURL(t *testing.T) {
+	stubRuntime(t)
+
+	const want = "https://github.com/owner/name/releases/tag/v1.2.3"
+
+	for _, repo := range []string{
+		"owner/name",
+		"github.com/owner/name",
+		"https://github.com/owner/name",
+		"http://github.com/owner/name/",
+		"https://github.com/owner/name.git",
+	} {
+		got, err := version.ReleaseURL(repo)
+		if err != nil {
+			t.Errorf("ReleaseURL(%q) error = %v", repo, err)
+		}
+
+		if got != want {
+			t.Errorf("ReleaseURL(%q) = %q, want %q", repo, got, want)
+		}
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestReleaseURLOnOtherHosts(t *testing.T) {
+	stubRuntime(t)
+	version.SetVersion("v2.0.0+build/5")
+
+	got, err := version.ReleaseURL("gitlab.com/owner/name")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "https://gitlab.com/owner/name/releases/tag/v2.0.0+build%2F5"; got != want {
+		t.Errorf("ReleaseURL() = %q, want %q", got, want)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestReleaseURLRejectsMalformedRepos(t *testing.T) {
+	stubRuntime(t)
+
+	for _, repo := range []string{"", "name", "a/b/c/d", "owner//name", "https:///owner/name"} {
+		if _, err := version.ReleaseURL(repo); !errors.Is(err, version.ErrInvalidRepo) {
+			t.Errorf("ReleaseURL(%q) error = %v, want %v", repo, err, version.ErrInvalidRepo)
+		}
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestReleaseURLOfUnknownVersion(t *testing.T) {
+	stubRuntime(t)
+	stubBuildInfo(t, func(bi *debug.BuildInfo) {
+		bi.Main.Version = "(devel)"
+	})
+
+	if _, err := version.ReleaseURL("owner/name"); !errors.Is(err, version.ErrUnknownVersion) {
+		t.Errorf("ReleaseURL() error = %v, want %v", err, version.ErrUnknownVersion)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestCommitURL(t *testing.T) {
+	stubRuntime(t)
+
+	got, err := version.CommitURL("github.com/owner/name")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "https://github.com/owner/name/commit/" + testRevision; got != want {
+		t.Errorf("CommitURL() = %q, want %q", got, want)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestCommitURLStripsDirtySuffix(t *testing.T) {
+	stubRuntime(t)
+	version.SetCommit("abc1234-dirty")
+
+	got, err := version.CommitURL("owner/name")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "https://github.com/owner/name/commit/abc1234"; got != want {
+		t.Errorf("CommitURL() = %q, want %q", got, want)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestCommitURLErrors(t *testing.T) {
+	stubRuntime(t)
+
+	if _, err := version.CommitURL("owner"); !errors.Is(err, version.ErrInvalidRepo) {
+		t.Errorf("CommitURL(\"owner\") error = %v, want %v", err, version.ErrInvalidRepo)
+	}
+
+	stubBuildInfo(t, func(bi *debug.BuildInfo) {
+		bi.Settings = nil
+	})
+
+	if _, err := version.CommitURL("owner/name"); !errors.Is(err, version.ErrUnknownCommit) {
+		t.Errorf("CommitURL() without a commit error = %v, want %v", err, version.ErrUnknownCommit)
+	}
+}
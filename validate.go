@@ -0,0 +1,61 @@
+package version
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ErrUnknownFields is returned by Validate when one or more fields could not be derived.
+var ErrUnknownFields = errors.New("version details have unknown values")
+
+// Validate returns an error listing every field that resolved to an unknown or default value, or nil if all of them
+// are known. A binary built with a full set of ldflags will pass, whereas a plain 'go build' will not, as the version
+// falls back to its default.
+func Validate() error {
+	i := Current()
+
+	checks := []struct {
+		name    string
+		value   string
+		unknown string
+	}{
+		{FieldExecutable, i.Executable, unknownValue},
+		{FieldVersion, i.Version, defaultVersion},
+		{FieldBuiltBy, i.BuiltBy, unknownValue},
+		{FieldCommit, i.Commit, unknownValue},
+		{FieldBuiltWith, i.BuiltWith, unknownValue},
+		{FieldBuildDate, i.BuildDate, unknownValue},
+	}
+
+	var unknown []string
+
+	for _, check := range checks {
+		if check.value == check.unknown {
+			unknown = append(unknown, check.name)
+		}
+	}
+
+	if len(unknown) > 0 {
+		return fmt.Errorf("%w: %s", ErrUnknownFields, strings.Join(unknown, ", "))
+	}
+
+	return nil
+}
+
+// MustBeStamped panics if Validate finds any unknown values, so that a binary built without a full set of ldflags
+// fails loudly instead of reaching production unidentified. It is intended to be called from 'main' in release builds.
+func MustBeStamped() {
+	if err := Validate(); err != nil {
+		panic(fmt.Sprintf("version: binary was not stamped at build time: %v", err))
+	}
+}
+
+// WarnIfUnstamped writes a warning to w if Validate finds any unknown values, and writes nothing otherwise.
+// This is the softer counterpart of MustBeStamped, for builds where an unstamped binary is unwelcome but not fatal.
+func WarnIfUnstamped(w io.Writer) {
+	if err := Validate(); err != nil {
+		fmt.Fprintf(w, "warning: binary was not stamped at build time: %v\n", err)
+	}
+}
@@ -0,0 +1,113 @@
+package version_test
+
+import (
+	"errors"
+	"io/fs"
+	"runtime/debug"
+	"strings"
+	"testing"
+
+	"go.jlucktay.dev/version"
+)
+
+//nolint:paralleltest // Overrides the package seams.
+func TestValidateAllKnown(t *testing.T) {
+	stubRuntime(t)
+
+	if err := version.Validate(); err != nil {
+		t.Errorf("Validate() = %v, want nil", err)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestValidateSomeUnknown(t *testing.T) {
+	stubRuntime(t)
+	stubBuildInfo(t, func(bi *debug.BuildInfo) {
+		bi.Main.Version = ""
+		bi.Settings = nil
+	})
+	version.Stub(t, version.OSExecutable, func() (string, error) {
+		return "", fs.ErrNotExist
+	})
+
+	err := version.Validate()
+	if !errors.Is(err, version.ErrUnknownFields) {
+		t.Fatalf("Validate() = %v, want %v", err, version.ErrUnknownFields)
+	}
+
+	if got, want := err.Error(), "executable, version, commit, buildDate"; !strings.HasSuffix(got, ": "+want) {
+		t.Errorf("Validate() = %q, want it to list %q", got, want)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestValidateWithLdflags(t *testing.T) {
+	version.Stub(t, version.ReadBuildInfo, func() (*debug.BuildInfo, bool) {
+		return nil, false
+	})
+
+	version.SetExecutable("app")
+	version.SetVersion("v1.0.0")
+	version.SetBuiltBy("ci")
+	version.SetCommit("abcdef0")
+	version.SetBuiltWith("go1.20.2")
+	version.SetBuildDate("2006-01-02T15:04:05Z")
+
+	if err := version.Validate(); err != nil {
+		t.Errorf("Validate() = %v, want nil", err)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestMustBeStamped(t *testing.T) {
+	stubRuntime(t)
+
+	func() {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				t.Errorf("MustBeStamped() of a stamped binary panicked with %v", recovered)
+			}
+		}()
+
+		version.MustBeStamped()
+	}()
+
+	stubBuildInfo(t, func(bi *debug.BuildInfo) {
+		bi.Main.Version = "(devel)"
+	})
+
+	defer func() {
+		recovered := recover()
+
+		message, ok := recovered.(string)
+		if !ok || !strings.Contains(message, "not stamped") || !strings.Contains(message, version.FieldVersion) {
+			t.Errorf("MustBeStamped() of an unstamped binary panicked with %#v, want a message naming the version", recovered)
+		}
+	}()
+
+	version.MustBeStamped()
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestWarnIfUnstamped(t *testing.T) {
+	stubRuntime(t)
+
+	var sb strings.Builder
+
+	version.WarnIfUnstamped(&sb)
+
+	if sb.Len() != 0 {
+		t.Errorf("WarnIfUnstamped() of a stamped binary wrote %q, want nothing", sb.String())
+	}
+
+	stubBuildInfo(t, func(bi *debug.BuildInfo) {
+		bi.Main.Version = "(devel)"
+	})
+
+	version.WarnIfUnstamped(&sb)
+
+	if want := "warning: binary was not stamped at build time: "; !strings.HasPrefix(sb.String(), want) ||
+		!strings.HasSuffix(sb.String(), ": version\n") {
+		t.Errorf("WarnIfUnstamped() of an unstamped binary wrote %q, want a warning naming the version", sb.String())
+	}
+}
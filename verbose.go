@@ -0,0 +1,95 @@
+package version
+
+import (
+	"fmt"
+	"strings"
+)
+
+// WithDependencies includes the modules compiled into the binary, as returned by Dependencies, in the output of
+// Verbose, and as a 'dependencies' array of objects with 'path', 'version', 'sum', and 'replace' keys at the end of
+// the output of JSON. The list is left out by default, to keep the output small.
+func WithDependencies() Option {
+	return func(o *options) {
+		o.dependencies = true
+	}
+}
+
+// Verbose returns a multi-line block describing the caller with aligned labels, as expected from the '--version
+// --verbose' output of a mature command line tool:
+//
+//	Executable: myapp
+//	Version:    v1.2.3
+//	Commit:     0123456789abcdef0123456789abcdef01234567
+//	Built by:   jlucktay
+//	Built with: go1.20.2
+//	Build date: 2006-01-02T15:04:05Z
+//	OS/Arch:    linux/amd64
+//
+// If the WithDependencies option is given, the block is followed by a 'Dependencies:' section listing each module on
+// its own indented line, along with any replacement. Rows for any fields dropped with WithOmit are left out, and the
+// 'OS/Arch' row is left out if either of its fields is. If the WithExecutablePath, WithHostname, or WithModulePath
+// options are given, a 'Path', 'Hostname', or 'Module' row respectively follows the others.
+func Verbose(opts ...Option) string {
+	o := newOptions(opts)
+	i := Current(opts...)
+
+	labelled := []struct {
+		field string
+		row   Field
+	}{
+		{FieldExecutable, Field{"Executable", i.Executable}},
+		{FieldVersion, Field{"Version", i.Version}},
+		{FieldCommit, Field{"Commit", i.Commit}},
+		{FieldBuiltBy, Field{"Built by", i.BuiltBy}},
+		{FieldBuiltWith, Field{"Built with", i.BuiltWith}},
+		{FieldBuildDate, Field{"Build date", i.BuildDate}},
+	}
+
+	rows := make([]Field, 0, len(labelled)+1)
+
+	for _, l := range labelled {
+		if !o.omit[l.field] {
+			rows = append(rows, l.row)
+		}
+	}
+
+	if !o.omit[FieldOS] && !o.omit[FieldArch] {
+		rows = append(rows, Field{"OS/Arch", i.OS + "/" + i.Arch})
+	}
+
+	if o.executablePath && !o.omit[FieldExecutablePath] {
+		rows = append(rows, Field{"Path", i.ExecutablePath})
+	}
+
+	if o.hostname && !o.omit[FieldHostname] {
+		rows = append(rows, Field{"Hostname", i.Hostname})
+	}
+
+	if o.modulePath && !o.omit[FieldModulePath] {
+		rows = append(rows, Field{"Module", i.ModulePath})
+	}
+
+	width := 0
+
+	for _, row := range rows {
+		if len(row.Name) > width {
+			width = len(row.Name)
+		}
+	}
+
+	var sb strings.Builder
+
+	for _, row := range rows {
+		fmt.Fprintf(&sb, "%-*s %s\n", width+1, row.Name+":", row.Value)
+	}
+
+	if o.dependencies {
+		sb.WriteString("Dependencies:\n")
+
+		for _, dep := range Dependencies() {
+			fmt.Fprintf(&sb, "  %s\n", dep)
+		}
+	}
+
+	return strings.TrimSuffix(sb.String(), "\n")
+}
@@ -0,0 +1,65 @@
+package version_test
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+
+	"go.jlucktay.dev/version"
+)
+
+//nolint:paralleltest // Overrides the package seams.
+func TestVerboseGolden(t *testing.T) {
+	stubRuntime(t)
+
+	// The platform row differs between the machines running the tests, so is checked separately.
+	assertGolden(t, "verbose", []byte(version.Verbose(version.WithOmit(version.FieldOS))))
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestVerboseIncludesPlatformRow(t *testing.T) {
+	stubRuntime(t)
+
+	lines := strings.Split(version.Verbose(), "\n")
+
+	if want := "OS/Arch:    " + runtime.GOOS + "/" + runtime.GOARCH; lines[len(lines)-1] != want {
+		t.Errorf("Verbose() last row = %q, want %q", lines[len(lines)-1], want)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestVerboseAlignsOptionalRows(t *testing.T) {
+	stubRuntime(t)
+
+	got := version.Verbose(
+		version.WithOmit(version.FieldOS),
+		version.WithExecutablePath(),
+		version.WithHostname(),
+		version.WithModulePath(),
+	)
+
+	for _, want := range []string{
+		"\nPath:       " + testExePath,
+		"\nHostname:   " + testHostname,
+		"\nModule:     " + testModulePath,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Verbose() =\n%s\nwant it to contain %q", got, want)
+		}
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestVerboseWithDependencies(t *testing.T) {
+	stubRuntime(t)
+
+	got := version.Verbose(version.WithOmit(version.FieldOS), version.WithDependencies())
+
+	want := version.Verbose(version.WithOmit(version.FieldOS)) + "\n" +
+		"Dependencies:\n" +
+		"  example.com/dep v0.1.0 h1:dep=\n" +
+		"  example.com/old v1.0.0 => example.com/new v1.0.1"
+	if got != want {
+		t.Errorf("Verbose(WithDependencies()) =\n%s\nwant\n%s", got, want)
+	}
+}
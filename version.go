@@ -12,35 +12,62 @@
 // One simple example of how to set ldflags when calling 'go build':
 //
 //	go build -ldflags="-X 'go.jlucktay.dev/version.version=v1.2.3'"
+//
+// Each value is resolved from the first of these sources to provide one, in order of precedence:
+//  1. A value given to one of the Set* functions, or otherwise the symbol set with ldflags.
+//  2. For the version only, a file read with LoadVersionFrom.
+//  3. If the WithEnvPrefix option is given, an environment variable named by the Env* constants and that prefix.
+//  4. A value derived from the runtime, as described on each symbol.
+//  5. A fallback of 'unknown', or whatever was given with WithUnknownValue.
+//
+// The runtime lookups behind the fallbacks are each made at most once and then cached, as their results cannot change
+// over the life of the process. The fallbacks themselves are applied into local state rather than the symbols, and
+// that state is cached in turn until one of the setters or LoadVersionFrom is next called. The symbols are guarded by a
+// lock wherever they can be overridden at runtime, so all of the functions in this package are safe for concurrent use
+// by multiple goroutines.
 package version
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/user"
 	"path/filepath"
+	"runtime"
 	"runtime/debug"
+	"strconv"
 	"strings"
 	"time"
 )
 
-// A fallback value if errors are returned when attempting to look up sensible defaults.
+// The default fallback value if errors are returned when attempting to look up sensible defaults, which can be
+// replaced with WithUnknownValue.
 const unknownValue = "unknown"
 
+// The fallback version used when none was set with ldflags.
+const defaultVersion = "v0.0.0-" + unknownValue
+
+// The main module version recorded in build info when the binary was not built from a tagged module.
+const develVersion = "(devel)"
+
 // These symbols can be populated with ldflags when building.
 //
 //nolint:gochecknoglobals // This is the whole point of this package.
 var (
 	// Executable is the name of the currently executing binary.
-	// Defaults to the base path of the string returned by calling 'os.Executable()'.
+	// Defaults to the base path of the string returned by calling 'os.Executable()', with any '.exe' suffix trimmed on
+	// Windows unless the WithExeSuffix option is given.
 	executable string
 
 	// Version is the semver-compatible git tag that this binary was built from.
-	// Defaults to 'v0.0.0-unknown'.
+	// Defaults to the 'Main.Version' field returned by calling 'debug.ReadBuildInfo()', as populated by 'go install'
+	// from a tagged module, or to 'v0.0.0-unknown' if that is empty or '(devel)'.
 	version string
 
 	// BuiltBy is the name of the user that built the currently executing binary.
-	// Defaults to the username returned by calling 'user.Current()'.
+	// Defaults to the username returned by calling 'user.Current()', or failing that to the numeric user ID from
+	// 'os.Getuid()' in the form 'uid:1000', except on Windows.
 	builtBy string
 
 	// Commit is the short hash of the commit that this binary was built from.
@@ -57,87 +84,445 @@ var (
 	buildDate string
 )
 
+// These indirections to the clock, the filesystem, and the runtime can be overridden, so that derived values are
+// predictable. As the results of most of them are cached, tests reset the cache after overriding one.
+//
+//nolint:gochecknoglobals // Seams for deterministic testing.
+var (
+	now           = time.Now
+	osExecutable  = os.Executable
+	osExit        = os.Exit
+	osGetuid      = os.Getuid
+	osHostname    = os.Hostname
+	osStat        = os.Stat
+	readBuildInfo = debug.ReadBuildInfo
+	runtimeGOOS   = runtime.GOOS
+	userCurrent   = user.Current
+)
+
+// Info holds the values describing the currently executing binary, with any fallbacks already applied.
+//
+// When marshalled to JSON, the keys are emitted in the order that the fields are declared below, and the key names are
+// considered stable: 'executable', 'version', 'builtBy', 'commit', 'builtWith', 'buildDate', 'os', 'arch',
+// 'runtimeGoVersion', 'cgoEnabled' when known, and 'executablePath', 'hostname', and 'modulePath' when asked for.
+//
+// Info holds only strings, so two values can be compared with '==' as well as with Equal. Anything that would stop it
+// from being comparable, such as the list of dependencies, is exposed through a function instead of a field.
+type Info struct {
+	Executable string `json:"executable"`
+	Version    string `json:"version"`
+	BuiltBy    string `json:"builtBy"`
+	Commit     string `json:"commit"`
+	BuiltWith  string `json:"builtWith"`
+	BuildDate  string `json:"buildDate"`
+
+	// OS and Arch are the operating system and architecture that the binary was compiled for, from 'runtime.GOOS' and
+	// 'runtime.GOARCH' respectively.
+	OS   string `json:"os"`
+	Arch string `json:"arch"`
+
+	// RuntimeGoVersion is the version of the Go runtime linked into the binary, from 'runtime.Version()'.
+	// This will usually match the toolchain recorded in build info, but BuiltWith may have been set with ldflags.
+	RuntimeGoVersion string `json:"runtimeGoVersion"`
+
+	// CGOEnabled is 'true' or 'false' depending on whether the binary was built with cgo enabled, or empty if the
+	// toolchain did not record this, or for a Build isolated from the host binary.
+	CGOEnabled string `json:"cgoEnabled,omitempty"`
+
+	// ExecutablePath is the absolute path of the binary, from 'os.Executable()', which is only filled in if the
+	// WithExecutablePath option is given.
+	ExecutablePath string `json:"executablePath,omitempty"`
+
+	// Hostname is the name of the host that the binary is running on, from 'os.Hostname()', which is only filled in if
+	// the WithHostname option is given. Unlike the other fields, it describes where the binary runs rather than how it
+	// was built.
+	Hostname string `json:"hostname,omitempty"`
+
+	// ModulePath is the path of the main module of the binary, from the 'Main.Path' field returned by calling
+	// 'debug.ReadBuildInfo()', which is only filled in if the WithModulePath option is given.
+	ModulePath string `json:"modulePath,omitempty"`
+}
+
 // Details returns a string describing the caller.
-func Details() string {
-	// Some variables we might need later.
+// Without any options, the string takes the form:
+//
+//	<executable> <version> built by <builtBy> from commit <commit> with <builtWith> at <buildDate>.
+//
+// The commit is abbreviated to ShortCommitLength characters, preserving any '-dirty' suffix, to keep the string to
+// a single readable line. This is a change from earlier releases, which showed the full hash; give WithFullCommit to
+// have it back, or WithShortCommit to choose another length.
+func Details(opts ...Option) string {
+	return std.Details(opts...)
+}
+
+// DetailsFull returns the string from Details extended with the target platform, as a single line to paste into a
+// support request, in the form:
+//
+//	<executable> <version> built by <builtBy> from commit <commit> with <builtWith> at <buildDate> on <os>/<arch>.
+//
+// It is the same as calling Details with WithPlatform.
+func DetailsFull(opts ...Option) string {
+	return Details(append([]Option{WithPlatform()}, opts...)...)
+}
+
+// Short returns a terse string identifying the caller, suitable for prefixing log lines, in the form:
+//
+//	<executable> <version> (<commit>)
+//
+// where the commit is abbreviated to ShortCommitLength characters.
+func Short() string {
+	return std.Short()
+}
+
+// VersionOnly returns the bare version of the caller with fallbacks applied, and nothing else, for capturing in scripts
+// and Makefiles. It is the terse end of the range of output that runs through Short and then Details, and returns the
+// same value as Version.
+func VersionOnly() string {
+	return Version()
+}
+
+// Fprint writes the same string that Details would return to w, without building it in memory first.
+// It returns the number of bytes written and any write error encountered.
+func Fprint(w io.Writer, opts ...Option) (int, error) {
+	return std.Fprint(w, opts...)
+}
+
+// Equal reports whether every field of the Info matches those of the other.
+func (i Info) Equal(other Info) bool {
+	return i == other
+}
+
+// WriteTo writes the Info to w in the same form that Details uses by default, satisfying the 'io.WriterTo' interface.
+// As with String, the commit is abbreviated to ShortCommitLength characters.
+func (i Info) WriteTo(w io.Writer) (int64, error) {
+	format, args := i.formatArgs()
+
+	n, err := fmt.Fprintf(w, format, args...)
+	if err != nil {
+		return int64(n), fmt.Errorf("writing version details: %w", err)
+	}
+
+	return int64(n), nil
+}
+
+// String returns the Info in the same form that Details uses by default, satisfying the 'fmt.Stringer' interface.
+// The fields are formatted as they are, without deriving any fallbacks, apart from the commit being abbreviated to
+// ShortCommitLength characters as it is by Details. The Commit field itself holds the full hash.
+func (i Info) String() string {
+	format, args := i.formatArgs()
+
+	return fmt.Sprintf(format, args...)
+}
+
+// formatArgs returns the format and arguments with which String and WriteTo render the Info, matching Details.
+func (i Info) formatArgs() (string, []any) {
+	o := options{shortCommit: ShortCommitLength}
+
+	return o.formatArgs(o.apply(i))
+}
+
+// GoString returns the Info as a Go composite literal listing only the fields that are set, such as
+// 'version.Info{Version:"v1.2.3", Commit:"abc"}', satisfying the 'fmt.GoStringer' interface for the '%#v' verb.
+func (i Info) GoString() string {
+	fields := []struct {
+		name  string
+		value string
+	}{
+		{"Executable", i.Executable},
+		{"Version", i.Version},
+		{"BuiltBy", i.BuiltBy},
+		{"Commit", i.Commit},
+		{"BuiltWith", i.BuiltWith},
+		{"BuildDate", i.BuildDate},
+		{"OS", i.OS},
+		{"Arch", i.Arch},
+		{"RuntimeGoVersion", i.RuntimeGoVersion},
+		{"CGOEnabled", i.CGOEnabled},
+		{"ExecutablePath", i.ExecutablePath},
+		{"Hostname", i.Hostname},
+		{"ModulePath", i.ModulePath},
+	}
+
+	set := make([]string, 0, len(fields))
+
+	for _, field := range fields {
+		if field.value != "" {
+			set = append(set, field.name+":"+strconv.Quote(field.value))
+		}
+	}
+
+	return "version.Info{" + strings.Join(set, ", ") + "}"
+}
+
+// InfoWithErrors returns the same Info as Current, along with an error for each runtime lookup that failed and so left
+// a field with its fallback value, such as from 'os.Executable()', 'user.Current()', 'os.Stat', or
+// 'debug.ReadBuildInfo()'. Each error wraps one of ErrExecutableLookup, ErrUserLookup, ErrHostnameLookup, or
+// ErrNoBuildInfo along with the underlying error, and the slice is empty if nothing failed, or if every field was
+// provided without a lookup.
+func InfoWithErrors(opts ...Option) (Info, []error) {
+	return std.InfoWithErrors(opts...)
+}
+
+// Current returns an Info describing the caller.
+// Any symbols that were not set with ldflags are looked up in the environment if WithEnvPrefix is given, or else
+// derived from the runtime, following the order of precedence described in the package documentation.
+// Fallbacks are never written back into the symbols, so a value given to a setter takes effect on the next call, but
+// the runtime lookups behind them are cached after the first call.
+func Current(opts ...Option) Info {
+	return std.Info(opts...)
+}
+
+// deriveWithErrors fills in any empty fields of the given seed from the environment and then the runtime, before
+// applying the field-level options, and returns an error for each runtime lookup that failed, in the order that the
+// lookups were made. A lookup whose result is shared by several fields contributes a single error.
+// Explicit values have surrounding whitespace trimmed, so that a stray newline from a shell substitution cannot break
+// the round trip through Details and Parse.
+func deriveWithErrors(i Info, o options) (Info, []error) {
+	i, errs := deriveFallbacks(i, o)
+
+	return o.apply(i), errs
+}
+
+// deriveFallbacks is like deriveWithErrors, but leaves the field-level options unapplied, so that its result can be
+// cached and shared between calls that differ only in those.
+func deriveFallbacks(i Info, o options) (Info, []error) {
+	i.trimSpace()
+
+	i.OS = runtimeGOOS
+	i.Arch = runtime.GOARCH
+	i.RuntimeGoVersion = runtime.Version()
+
+	// Fill any gaps from the environment, if asked to, unless isolated from the host binary.
+	if o.env && o.host {
+		i.fillFromEnv(o.envPrefix)
+		i.trimSpace()
+	}
+
+	// Each runtime lookup is made only within the branch for a field that still needs it, so that nothing is looked up
+	// for a field that was already provided.
 	var (
-		exePath   string
-		buildInfo *debug.BuildInfo
+		cache = runtimeLookups()
+		errs  lookupErrors
 	)
 
-	// Pre-populate these if they are needed.
-	if executable == "" || buildDate == "" {
-		var err error
+	executablePath := func() (string, bool) {
+		exePath, err := cache.executablePath()
+		errs.add(ErrExecutableLookup, err)
 
-		exePath, err = os.Executable()
-		if err != nil {
-			exePath = unknownValue
-		}
+		return exePath, err == nil
 	}
 
-	if commit == "" || builtWith == "" {
-		var biOK bool
-		buildInfo, biOK = debug.ReadBuildInfo()
+	buildInfo := func() (*debug.BuildInfo, bool) {
+		bi, ok := cache.readBuildInfo()
+		if !ok || bi == nil {
+			errs.add(ErrNoBuildInfo, ErrNoBuildInfo)
 
-		if !biOK && commit == "" {
-			commit = unknownValue
+			return nil, false
 		}
 
-		if !biOK && builtWith == "" {
-			commit = unknownValue
+		return bi, true
+	}
+
+	// The name, version, commit, and builder of the host binary are not borrowed for an isolated Build, such as that of
+	// a library, while the toolchain and build date are shared by everything compiled into it.
+	hostExecutablePath := func() (string, bool) {
+		if !o.host {
+			return "", false
 		}
+
+		return executablePath()
 	}
 
-	// Check each symbol in turn, and populate if not already set.
-	if executable == "" {
-		if exePath != unknownValue {
-			executable = filepath.Base(exePath)
-		} else {
-			executable = unknownValue
+	hostBuildInfo := func() (*debug.BuildInfo, bool) {
+		if !o.host {
+			return nil, false
 		}
+
+		return buildInfo()
 	}
 
-	if version == "" {
-		version = "v0.0.0-" + unknownValue
+	if i.Executable == "" {
+		i.Executable = o.unknown
+
+		if exePath, ok := hostExecutablePath(); ok {
+			i.Executable = filepath.Base(exePath)
+
+			if runtimeGOOS == "windows" && !o.keepExeSuffix {
+				i.Executable = trimExeSuffix(i.Executable)
+			}
+		}
 	}
 
-	if builtBy == "" {
-		u, err := user.Current()
-		if err != nil {
-			builtBy = unknownValue
-		} else {
-			builtBy = u.Username
+	if i.Version == "" {
+		if bi, ok := hostBuildInfo(); ok && bi.Main.Version != develVersion {
+			i.Version = bi.Main.Version
 		}
 	}
 
-	if commit == "" {
-		for index := range buildInfo.Settings {
-			switch strings.ToLower(buildInfo.Settings[index].Key) {
-			case "vcs.revision":
-				commit = buildInfo.Settings[index].Value + commit
-			case "vcs.modified":
-				if strings.EqualFold(buildInfo.Settings[index].Value, "true") {
-					commit += "-dirty"
-				}
+	if i.Version == "" {
+		i.Version = o.unknownVersion
+	}
+
+	if i.BuiltBy == "" {
+		i.BuiltBy = o.unknown
+
+		if o.host {
+			name, err := cache.usernameBefore(o.userLookupDone)
+			if name != "" {
+				i.BuiltBy = name
 			}
+
+			errs.add(ErrUserLookup, err)
+		}
+	}
+
+	if i.Commit == "" {
+		if bi, ok := hostBuildInfo(); ok {
+			i.Commit = commitFromSettings(bi.Settings)
 		}
 	}
 
-	if builtWith == "" {
-		builtWith = buildInfo.GoVersion
+	if i.Commit == "" {
+		i.Commit = o.unknown
+	}
+
+	if i.BuiltWith == "" {
+		i.BuiltWith = o.unknown
+
+		if bi, ok := buildInfo(); ok {
+			i.BuiltWith = bi.GoVersion
+		}
 	}
 
-	if buildDate == "" {
-		buildDate = unknownValue
+	if i.BuildDate != "" {
+		i.BuildDate = o.formatDate(i.BuildDate)
+	} else {
+		i.BuildDate = o.unknown
 
-		if exePath != unknownValue {
-			fi, err := os.Stat(exePath)
+		if _, ok := executablePath(); ok {
+			modTime, err := cache.executableModTime()
 			if err == nil {
-				buildDate = fi.ModTime().Format(time.RFC3339)
+				i.BuildDate = o.formatTime(modTime)
 			}
+
+			errs.add(ErrExecutableLookup, err)
 		}
 	}
 
-	return fmt.Sprintf("%s %s built by %s from commit %s with %s at %s.",
-		executable, version, builtBy, commit, builtWith, buildDate)
+	if o.executablePath {
+		i.ExecutablePath = o.unknown
+
+		if exePath, ok := executablePath(); ok {
+			i.ExecutablePath = exePath
+		}
+	}
+
+	if o.hostname {
+		i.Hostname = o.unknown
+
+		name, err := osHostname()
+		if err == nil {
+			i.Hostname = name
+		}
+
+		errs.add(ErrHostnameLookup, err)
+	}
+
+	if o.modulePath {
+		i.ModulePath = o.unknown
+
+		if bi, ok := buildInfo(); ok && bi.Main.Path != "" {
+			i.ModulePath = bi.Main.Path
+		}
+	}
+
+	// The cgo setting is read from the build info of the host binary, so it is not reported for an isolated Build.
+	if o.host {
+		if enabled, err := CGOEnabled(); err == nil {
+			i.CGOEnabled = strconv.FormatBool(enabled)
+		}
+	}
+
+	return i, errs.errs
+}
+
+// These errors are wrapped around the failures of the runtime lookups reported by InfoWithErrors, alongside
+// ErrNoBuildInfo, so that they can be told apart with 'errors.Is'. The underlying error is wrapped too.
+var (
+	// ErrExecutableLookup is wrapped around a failure to find the currently executing binary with 'os.Executable()',
+	// or to read its modification time with 'os.Stat'.
+	ErrExecutableLookup = errors.New("looking up executable")
+
+	// ErrUserLookup is wrapped around a failure to find the current user with 'user.Current()', such as in a container
+	// without an '/etc/passwd' file. It is reported even when the numeric user ID stands in for the name.
+	ErrUserLookup = errors.New("looking up current user")
+
+	// ErrHostnameLookup is wrapped around a failure to find the name of the host with 'os.Hostname()'.
+	ErrHostnameLookup = errors.New("looking up hostname")
+)
+
+// lookupErrors collects the errors from failed runtime lookups, keeping only the first for each sentinel.
+type lookupErrors struct {
+	errs []error
+	seen map[error]bool
+}
+
+// add records the given error wrapped with the sentinel, unless it is nil or the sentinel was already recorded.
+func (le *lookupErrors) add(sentinel, err error) {
+	if err == nil || le.seen[sentinel] {
+		return
+	}
+
+	if le.seen == nil {
+		le.seen = make(map[error]bool)
+	}
+
+	le.seen[sentinel] = true
+
+	if errors.Is(err, sentinel) {
+		le.errs = append(le.errs, err)
+	} else {
+		le.errs = append(le.errs, wrapSentinel(sentinel, "", err))
+	}
+}
+
+// commitFromSettings returns the revision recorded in the given build settings, with the dirty suffix appended if the
+// working tree was modified, or an empty string if no revision was recorded.
+func commitFromSettings(settings []debug.BuildSetting) string {
+	var revision, suffix string
+
+	for index := range settings {
+		switch strings.ToLower(settings[index].Key) {
+		case settingRevision:
+			revision = settings[index].Value
+		case settingModified:
+			if strings.EqualFold(settings[index].Value, "true") {
+				suffix = dirtySuffix
+			}
+		}
+	}
+
+	if revision == "" {
+		return ""
+	}
+
+	return revision + suffix
+}
+
+// trimSpace removes surrounding whitespace from each of the fields that can be set explicitly.
+func (i *Info) trimSpace() {
+	for _, value := range []*string{&i.Executable, &i.Version, &i.BuiltBy, &i.Commit, &i.BuiltWith, &i.BuildDate} {
+		*value = strings.TrimSpace(*value)
+	}
+}
+
+// trimExeSuffix removes a trailing '.exe' from the given executable name, regardless of case.
+func trimExeSuffix(name string) string {
+	const suffix = ".exe"
+
+	if len(name) > len(suffix) && strings.EqualFold(name[len(name)-len(suffix):], suffix) {
+		return name[:len(name)-len(suffix)]
+	}
+
+	return name
 }
@@ -0,0 +1,669 @@
+package version_test
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os/user"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"testing"
+	"testing/fstest"
+
+	"go.jlucktay.dev/version"
+)
+
+//nolint:paralleltest // Overrides the package seams.
+func TestCurrentDerivesFallbacksFromRuntime(t *testing.T) {
+	stubRuntime(t)
+
+	want := version.Info{
+		Executable:       testExecutable,
+		Version:          testVersion,
+		BuiltBy:          testUser,
+		Commit:           testRevision,
+		BuiltWith:        testGoVersion,
+		BuildDate:        testBuildDate,
+		OS:               runtime.GOOS,
+		Arch:             runtime.GOARCH,
+		RuntimeGoVersion: runtime.Version(),
+		CGOEnabled:       "true",
+	}
+
+	if got := version.Current(); got != want {
+		t.Errorf("Current() =\n%#v\nwant\n%#v", got, want)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestCurrentPrefersExplicitValues(t *testing.T) {
+	stubRuntime(t)
+
+	version.SetExecutable("explicit")
+	version.SetVersion("v9.8.7")
+	version.SetBuiltBy("builder")
+	version.SetCommit("fedcba9")
+	version.SetBuiltWith("go1.19.1")
+	version.SetBuildDate("2020-02-20T20:20:20Z")
+
+	got := version.Current()
+
+	for _, check := range []struct{ name, got, want string }{
+		{"Executable", got.Executable, "explicit"},
+		{"Version", got.Version, "v9.8.7"},
+		{"BuiltBy", got.BuiltBy, "builder"},
+		{"Commit", got.Commit, "fedcba9"},
+		{"BuiltWith", got.BuiltWith, "go1.19.1"},
+		{"BuildDate", got.BuildDate, "2020-02-20T20:20:20Z"},
+	} {
+		if check.got != check.want {
+			t.Errorf("Current().%s = %q, want %q", check.name, check.got, check.want)
+		}
+	}
+}
+
+// errBrokenPipe is returned by a failingWriter once it has accepted its limit.
+var errBrokenPipe = errors.New("broken pipe")
+
+// failingWriter accepts up to limit bytes, and then fails the write that would exceed it.
+type failingWriter struct {
+	limit   int
+	written strings.Builder
+}
+
+func (fw *failingWriter) Write(p []byte) (int, error) {
+	if remaining := fw.limit - fw.written.Len(); len(p) > remaining {
+		fw.written.Write(p[:remaining])
+
+		return remaining, errBrokenPipe
+	}
+
+	return fw.written.Write(p) //nolint:wrapcheck // A strings.Builder never fails a write.
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestFprintWritesDetails(t *testing.T) {
+	stubRuntime(t)
+
+	var sb strings.Builder
+
+	n, err := version.Fprint(&sb)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := version.Details(); sb.String() != want || n != len(want) {
+		t.Errorf("Fprint() wrote %q (%d bytes), want %q (%d bytes)", sb.String(), n, want, len(want))
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestFprintReportsWriteErrors(t *testing.T) {
+	stubRuntime(t)
+
+	writer := &failingWriter{limit: 10}
+
+	n, err := version.Fprint(writer)
+	if !errors.Is(err, errBrokenPipe) {
+		t.Errorf("Fprint() error = %v, want %v", err, errBrokenPipe)
+	}
+
+	if n != writer.limit || writer.written.String() != version.Details()[:writer.limit] {
+		t.Errorf("Fprint() wrote %q (%d bytes), want the first %d bytes of Details",
+			writer.written.String(), n, writer.limit)
+	}
+}
+
+func TestInfoWriteTo(t *testing.T) {
+	t.Parallel()
+
+	info := version.Info{
+		Executable: "app",
+		Version:    "v1.0.0",
+		BuiltBy:    "someone",
+		Commit:     "abcdef0",
+		BuiltWith:  "go1.20.2",
+		BuildDate:  "2006-01-02T15:04:05Z",
+	}
+
+	want := "app v1.0.0 built by someone from commit abcdef0 with go1.20.2 at 2006-01-02T15:04:05Z."
+
+	var sb strings.Builder
+
+	n, err := info.WriteTo(&sb)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if sb.String() != want || n != int64(len(want)) {
+		t.Errorf("WriteTo() wrote %q (%d bytes), want %q", sb.String(), n, want)
+	}
+
+	n, err = info.WriteTo(&failingWriter{limit: 3})
+	if !errors.Is(err, errBrokenPipe) || n != 3 {
+		t.Errorf("WriteTo() on a failing writer = %d, %v, want 3, %v", n, err, errBrokenPipe)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestBuiltWithFallsBackWithoutBuildInfo(t *testing.T) {
+	stubRuntime(t)
+	version.Stub(t, version.ReadBuildInfo, func() (*debug.BuildInfo, bool) {
+		return nil, false
+	})
+	version.SetCommit("explicit")
+
+	got := version.Current()
+
+	if got.BuiltWith != "unknown" {
+		t.Errorf("Current().BuiltWith = %q, want %q", got.BuiltWith, "unknown")
+	}
+
+	if got.Commit != "explicit" {
+		t.Errorf("Current().Commit = %q, want the explicit value to survive", got.Commit)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestDetailsWithoutBuildInfo(t *testing.T) {
+	testCases := map[string]func() (*debug.BuildInfo, bool){
+		"failed": func() (*debug.BuildInfo, bool) {
+			return nil, false
+		},
+		"nil despite ok": func() (*debug.BuildInfo, bool) {
+			return nil, true
+		},
+	}
+
+	want := "testapp v0.0.0-unknown built by tester from commit unknown with unknown at 2006-01-02T15:04:05Z."
+
+	for name, readBuildInfo := range testCases {
+		readBuildInfo := readBuildInfo
+
+		t.Run(name, func(t *testing.T) {
+			stubRuntime(t)
+			version.Stub(t, version.ReadBuildInfo, readBuildInfo)
+
+			if got := version.Details(); got != want {
+				t.Errorf("Details() = %q, want %q", got, want)
+			}
+
+			if got := version.Current().CGOEnabled; got != "" {
+				t.Errorf("Current().CGOEnabled = %q, want it left empty", got)
+			}
+		})
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestDetailsLeavesSymbolsUntouched(t *testing.T) {
+	stubRuntime(t)
+
+	first := version.Details()
+
+	if seed := version.Seed(); seed != (version.Info{}) {
+		t.Errorf("symbols after Details() = %#v, want them left empty", seed)
+	}
+
+	if second := version.Details(); second != first {
+		t.Errorf("second Details() = %q, want %q", second, first)
+	}
+
+	version.SetVersion("v2.0.0")
+
+	if got := version.Version(); got != "v2.0.0" {
+		t.Errorf("Version() after SetVersion = %q, want the fallback not to have been frozen", got)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestConcurrentFirstCalls(t *testing.T) {
+	stubRuntime(t)
+
+	const goroutines = 50
+
+	var (
+		wg      sync.WaitGroup
+		details = make([]string, goroutines)
+		infos   = make([]version.Info, goroutines)
+	)
+
+	for index := 0; index < goroutines; index++ {
+		wg.Add(1)
+
+		go func(index int) {
+			defer wg.Done()
+
+			details[index] = version.Details()
+			infos[index] = version.Current()
+		}(index)
+	}
+
+	wg.Wait()
+
+	for index := 1; index < goroutines; index++ {
+		if details[index] != details[0] || infos[index] != infos[0] {
+			t.Fatalf("goroutine %d saw %q and %#v, want %q and %#v",
+				index, details[index], infos[index], details[0], infos[0])
+		}
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestCurrentFromBuildInfo(t *testing.T) {
+	testCases := map[string]struct {
+		modify func(*debug.BuildInfo)
+		want   version.Info
+	}{
+		"tagged": {
+			modify: func(*debug.BuildInfo) {},
+			want:   version.Info{Version: testVersion, Commit: testRevision, BuiltWith: testGoVersion},
+		},
+		"devel": {
+			modify: func(bi *debug.BuildInfo) {
+				bi.Main.Version = "(devel)"
+			},
+			want: version.Info{Version: "v0.0.0-unknown", Commit: testRevision, BuiltWith: testGoVersion},
+		},
+		"dirty": {
+			modify: func(bi *debug.BuildInfo) {
+				bi.Settings[len(bi.Settings)-1].Value = "true"
+			},
+			want: version.Info{Version: testVersion, Commit: testRevision + "-dirty", BuiltWith: testGoVersion},
+		},
+		"no vcs": {
+			modify: func(bi *debug.BuildInfo) {
+				bi.Settings = nil
+			},
+			want: version.Info{Version: testVersion, Commit: "unknown", BuiltWith: testGoVersion},
+		},
+		"other toolchain": {
+			modify: func(bi *debug.BuildInfo) {
+				bi.GoVersion = "go1.19.5"
+			},
+			want: version.Info{Version: testVersion, Commit: testRevision, BuiltWith: "go1.19.5"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(name, func(t *testing.T) {
+			stubRuntime(t)
+			stubBuildInfo(t, testCase.modify)
+
+			got := version.Current()
+			got = version.Info{Version: got.Version, Commit: got.Commit, BuiltWith: got.BuiltWith}
+
+			if got != testCase.want {
+				t.Errorf("Current() = %#v, want %#v", got, testCase.want)
+			}
+		})
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestCurrentWithFailingExecutableLookup(t *testing.T) {
+	stubRuntime(t)
+
+	version.Stub(t, version.OSExecutable, func() (string, error) {
+		return "", fs.ErrNotExist
+	})
+
+	version.Stub(t, version.OSStat, func(string) (fs.FileInfo, error) {
+		t.Error("os.Stat called without an executable path")
+
+		return nil, fs.ErrNotExist
+	})
+
+	got := version.Current()
+
+	if got.Executable != "unknown" || got.BuildDate != "unknown" {
+		t.Errorf("Current() executable and build date = %q and %q, want both unknown", got.Executable, got.BuildDate)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestCurrentWithFailingUserLookup(t *testing.T) {
+	stubRuntime(t)
+
+	version.Stub(t, version.UserCurrent, func() (*user.User, error) {
+		return nil, user.UnknownUserIdError(1000)
+	})
+
+	version.Stub(t, version.OSGetuid, func() int {
+		return -1
+	})
+
+	if got := version.BuiltBy(); got != "unknown" {
+		t.Errorf("BuiltBy() = %q, want %q", got, "unknown")
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestCurrentFromUserLookup(t *testing.T) {
+	stubRuntime(t)
+
+	version.Stub(t, version.UserCurrent, func() (*user.User, error) {
+		return &user.User{Username: "alice", Uid: "1001"}, nil
+	})
+
+	if got := version.BuiltBy(); got != "alice" {
+		t.Errorf("BuiltBy() = %q, want %q", got, "alice")
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestVersionFromModuleInfo(t *testing.T) {
+	testCases := map[string]struct {
+		mainVersion string
+		ldflag      string
+		want        string
+	}{
+		"tagged module":    {mainVersion: "v2.3.4", want: "v2.3.4"},
+		"devel":            {mainVersion: "(devel)", want: "v0.0.0-unknown"},
+		"empty":            {mainVersion: "", want: "v0.0.0-unknown"},
+		"ldflag preferred": {mainVersion: "v2.3.4", ldflag: "v9.9.9", want: "v9.9.9"},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(name, func(t *testing.T) {
+			stubRuntime(t)
+			stubBuildInfo(t, func(bi *debug.BuildInfo) {
+				bi.Main.Version = testCase.mainVersion
+			})
+			version.SetVersion(testCase.ldflag)
+
+			if got := version.Version(); got != testCase.want {
+				t.Errorf("Version() = %q, want %q", got, testCase.want)
+			}
+		})
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestShort(t *testing.T) {
+	stubRuntime(t)
+
+	if got, want := version.Short(), "testapp v1.2.3 (0123456)"; got != want {
+		t.Errorf("Short() = %q, want %q", got, want)
+	}
+
+	version.SetCommit("abc")
+
+	if got, want := version.Short(), "testapp v1.2.3 (abc)"; got != want {
+		t.Errorf("Short() with a short commit = %q, want %q", got, want)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestExecutableSuffix(t *testing.T) {
+	// The suffix is only trimmed on Windows, where every executable has one.
+	testCases := map[string]struct {
+		name        string
+		opts        []version.Option
+		wantWindows string
+		wantOther   string
+	}{
+		"exe":          {"testapp.exe", nil, "testapp", "testapp.exe"},
+		"upper case":   {"testapp.EXE", nil, "testapp", "testapp.EXE"},
+		"kept":         {"testapp.exe", []version.Option{version.WithExeSuffix()}, "testapp.exe", "testapp.exe"},
+		"only suffix":  {".exe", nil, ".exe", ".exe"},
+		"other suffix": {"testapp.bin", nil, "testapp.bin", "testapp.bin"},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+
+		for _, goos := range []string{"windows", "linux", "darwin"} {
+			goos := goos
+
+			want := testCase.wantOther
+			if goos == "windows" {
+				want = testCase.wantWindows
+			}
+
+			t.Run(name+" on "+goos, func(t *testing.T) {
+				stubRuntime(t)
+				version.Stub(t, version.RuntimeGOOS, goos)
+				version.Stub(t, version.OSExecutable, func() (string, error) {
+					return "/opt/testapp/bin/" + testCase.name, nil
+				})
+
+				if got := version.Current(testCase.opts...).Executable; got != want {
+					t.Errorf("Current().Executable = %q, want %q", got, want)
+				}
+			})
+		}
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestExecutableSuffixKeptFromLdflags(t *testing.T) {
+	stubRuntime(t)
+	version.SetExecutable("explicit.exe")
+
+	if got := version.Executable(); got != "explicit.exe" {
+		t.Errorf("Executable() = %q, want the ldflag left unaltered", got)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestReset(t *testing.T) {
+	stubRuntime(t)
+
+	version.SetVersion("v5.0.0")
+	version.SetCommit("abcdef0")
+
+	if err := version.Register("app", version.Default()); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := version.LoadVersionFrom(fstest.MapFS{"VERSION": {Data: []byte("v3.1.4")}}, "VERSION"); err != nil {
+		t.Fatal(err)
+	}
+
+	before := version.BuiltBy()
+
+	version.Reset()
+
+	if seed := version.Seed(); seed != (version.Info{}) {
+		t.Errorf("symbols after Reset() = %#v, want them empty", seed)
+	}
+
+	if got := version.Version(); got != testVersion {
+		t.Errorf("Version() after Reset() = %q, want the fallback %q", got, testVersion)
+	}
+
+	if _, ok := version.Get("app"); ok {
+		t.Error("Get() after Reset() found a registered Build")
+	}
+
+	// The cached lookups are discarded, so that a seam overridden afterwards takes effect.
+	version.Stub(t, version.UserCurrent, func() (*user.User, error) {
+		return &user.User{Username: "someone-else"}, nil
+	})
+
+	if after := version.BuiltBy(); after == before {
+		t.Errorf("BuiltBy() after Reset() = %q, want the lookup made again", after)
+	}
+}
+
+func TestInfoEqual(t *testing.T) {
+	t.Parallel()
+
+	info := version.Info{Executable: "myapp", Version: "v1.2.3", Commit: "abc", ModulePath: "example.com/myapp"}
+	same := info
+
+	if !info.Equal(same) || info != same {
+		t.Errorf("Equal() and == of identical values = %t and %t, want true", info.Equal(same), info == same)
+	}
+
+	// Every field takes part in the comparison, including those only filled in by options.
+	for _, modify := range []func(*version.Info){
+		func(i *version.Info) { i.Version = "v1.2.4" },
+		func(i *version.Info) { i.Commit = "abc-dirty" },
+		func(i *version.Info) { i.Hostname = "buildhost" },
+		func(i *version.Info) { i.ModulePath = "" },
+	} {
+		other := info
+		modify(&other)
+
+		if info.Equal(other) || info == other {
+			t.Errorf("Equal() and == of %#v and %#v = %t and %t, want false",
+				info, other, info.Equal(other), info == other)
+		}
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestVersionOnly(t *testing.T) {
+	stubRuntime(t)
+
+	if got := version.VersionOnly(); got != testVersion {
+		t.Errorf("VersionOnly() = %q, want %q", got, testVersion)
+	}
+
+	version.SetVersion("  v9.8.7\n")
+
+	if got := version.VersionOnly(); got != "v9.8.7" {
+		t.Errorf("VersionOnly() with an explicit version = %q, want %q with no surrounding whitespace", got, "v9.8.7")
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestVersionOnlyFallsBack(t *testing.T) {
+	stubRuntime(t)
+	version.Stub(t, version.ReadBuildInfo, func() (*debug.BuildInfo, bool) {
+		return nil, false
+	})
+
+	if got := version.VersionOnly(); got != "v0.0.0-unknown" {
+		t.Errorf("VersionOnly() without build info = %q, want %q", got, "v0.0.0-unknown")
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestInfoStringMatchesDetails(t *testing.T) {
+	stubRuntime(t)
+
+	info := version.Current()
+
+	if got, want := fmt.Sprint(info), version.Details(); got != want {
+		t.Errorf("fmt.Sprint(Current()) = %q, want %q", got, want)
+	}
+
+	if got, want := fmt.Sprintf("%v", &info), version.Details(); got != want {
+		t.Errorf("fmt.Sprintf(%%v, &info) = %q, want %q", got, want)
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestInfoStringDoesNotDerive(t *testing.T) {
+	version.Stub(t, version.OSExecutable, func() (string, error) {
+		t.Error("os.Executable was called")
+
+		return testExePath, nil
+	})
+
+	info := version.Info{Executable: "myapp", Version: "v1.2.3", Commit: "0123456789abcdef-dirty"}
+
+	if got, want := info.String(), "myapp v1.2.3 built by  from commit 0123456-dirty with  at ."; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	if info.Commit != "0123456789abcdef-dirty" {
+		t.Errorf("String() changed the commit to %q, want the full hash kept", info.Commit)
+	}
+}
+
+func TestInfoGoString(t *testing.T) {
+	t.Parallel()
+
+	info := version.Info{Version: "v1.2.3", Commit: "abc", Hostname: `host "one"`}
+
+	want := `version.Info{Version:"v1.2.3", Commit:"abc", Hostname:"host \"one\""}`
+	if got := fmt.Sprintf("%#v", info); got != want {
+		t.Errorf("fmt.Sprintf(%%#v) = %s, want %s", got, want)
+	}
+
+	if got := (version.Info{}).GoString(); got != "version.Info{}" {
+		t.Errorf("GoString() of an empty Info = %s, want %s", got, "version.Info{}")
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestDetailsAbbreviatesCommit(t *testing.T) {
+	stubRuntime(t)
+
+	testCases := map[string]struct {
+		commit string
+		opts   []version.Option
+		want   string
+	}{
+		"default length":    {testRevision, nil, "0123456"},
+		"dirty suffix kept": {testRevision + "-dirty", nil, "0123456-dirty"},
+		"full commit":       {testRevision, []version.Option{version.WithFullCommit()}, testRevision},
+		"full dirty commit": {
+			testRevision + "-dirty", []version.Option{version.WithFullCommit()}, testRevision + "-dirty",
+		},
+		"chosen length":        {testRevision, []version.Option{version.WithShortCommit(12)}, "0123456789ab"},
+		"shorter than default": {"abc", nil, "abc"},
+		"last option wins": {
+			testRevision, []version.Option{version.WithFullCommit(), version.WithShortCommit(4)}, "0123",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(name, func(t *testing.T) {
+			version.SetCommit(testCase.commit)
+
+			want := "testapp v1.2.3 built by tester from commit " + testCase.want +
+				" with go1.20.2 at 2006-01-02T15:04:05Z."
+			if got := version.Details(testCase.opts...); got != want {
+				t.Errorf("Details() = %q, want %q", got, want)
+			}
+
+			// Without any options, the Info itself keeps the full commit.
+			if got := version.Current().Commit; got != testCase.commit {
+				t.Errorf("Current().Commit = %q, want %q", got, testCase.commit)
+			}
+		})
+	}
+}
+
+//nolint:paralleltest // Overrides the package seams.
+func TestStringAndWriteToMatchDetails(t *testing.T) {
+	stubRuntime(t)
+	version.SetCommit(testRevision + "-dirty")
+
+	info := version.Current()
+	want := version.Details()
+
+	if info.Commit != testRevision+"-dirty" {
+		t.Errorf("Current().Commit = %q, want the full commit", info.Commit)
+	}
+
+	if got := info.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	var sb strings.Builder
+	if _, err := info.WriteTo(&sb); err != nil {
+		t.Fatal(err)
+	}
+
+	if sb.String() != want {
+		t.Errorf("WriteTo() wrote %q, want %q", sb.String(), want)
+	}
+
+	if !strings.Contains(want, " from commit 0123456-dirty ") {
+		t.Errorf("Details() = %q, want the abbreviated dirty commit", want)
+	}
+}
@@ -0,0 +1,56 @@
+// Package yamlversion renders the version details of the currently executing binary as YAML.
+package yamlversion
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+
+	"go.jlucktay.dev/version"
+)
+
+// document mirrors version.Info with snake_case keys. The keys are emitted in the order that the fields are declared
+// below, which matches the order of the JSON keys documented on version.Info.
+type document struct {
+	Executable       string `yaml:"executable"`
+	Version          string `yaml:"version"`
+	BuiltBy          string `yaml:"built_by"`
+	Commit           string `yaml:"commit"`
+	BuiltWith        string `yaml:"built_with"`
+	BuildDate        string `yaml:"build_date"`
+	OS               string `yaml:"os"`
+	Arch             string `yaml:"arch"`
+	RuntimeGoVersion string `yaml:"runtime_go_version"`
+	CGOEnabled       string `yaml:"cgo_enabled,omitempty"`
+	ExecutablePath   string `yaml:"executable_path,omitempty"`
+	Hostname         string `yaml:"hostname,omitempty"`
+	ModulePath       string `yaml:"module_path,omitempty"`
+}
+
+// YAML returns the YAML encoding of the given Info as a single mapping.
+// The keys are the snake_case forms of the JSON keys, in the same order: 'executable', 'version', 'built_by', 'commit',
+// 'built_with', 'build_date', 'os', 'arch', 'runtime_go_version', 'cgo_enabled' when known, and
+// 'executable_path', 'hostname', and 'module_path' when asked for.
+// Every value is encoded as a string, quoted where YAML would otherwise read it as another type.
+func YAML(i version.Info) ([]byte, error) {
+	b, err := yaml.Marshal(document{
+		Executable:       i.Executable,
+		Version:          i.Version,
+		BuiltBy:          i.BuiltBy,
+		Commit:           i.Commit,
+		BuiltWith:        i.BuiltWith,
+		BuildDate:        i.BuildDate,
+		OS:               i.OS,
+		Arch:             i.Arch,
+		RuntimeGoVersion: i.RuntimeGoVersion,
+		CGOEnabled:       i.CGOEnabled,
+		ExecutablePath:   i.ExecutablePath,
+		Hostname:         i.Hostname,
+		ModulePath:       i.ModulePath,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshalling version info as YAML: %w", err)
+	}
+
+	return b, nil
+}
@@ -0,0 +1,109 @@
+package yamlversion_test
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"go.jlucktay.dev/version"
+	"go.jlucktay.dev/version/yamlversion"
+)
+
+// Rewrites the golden files under testdata with the output of the tests, as in 'go test ./... -update'.
+//
+//nolint:gochecknoglobals // Flags are registered at package initialisation.
+var update = flag.Bool("update", false, "rewrite the golden files under testdata")
+
+// testInfo has values for every field, including some that YAML would read as other types if left unquoted.
+func testInfo() version.Info {
+	return version.Info{
+		Executable:       "myapp",
+		Version:          "1.20",
+		BuiltBy:          "yes",
+		Commit:           "0123456789abcdef0123456789abcdef01234567",
+		BuiltWith:        "go1.20.2",
+		BuildDate:        "2006-01-02T15:04:05Z",
+		OS:               "linux",
+		Arch:             "amd64",
+		RuntimeGoVersion: "go1.20.3",
+		CGOEnabled:       "true",
+		ExecutablePath:   "/opt/myapp/bin/myapp",
+		Hostname:         "null",
+		ModulePath:       "example.com/myapp",
+	}
+}
+
+func TestYAMLGolden(t *testing.T) {
+	t.Parallel()
+
+	got, err := yamlversion.YAML(testInfo())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join("testdata", "yaml.golden")
+
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(path, got, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading golden file: %v", err)
+	}
+
+	if !bytes.Equal(got, want) {
+		t.Errorf("output does not match %s:\ngot:\n%s\nwant:\n%s", path, got, want)
+	}
+}
+
+func TestYAMLKeepsEveryValueAString(t *testing.T) {
+	t.Parallel()
+
+	got, err := yamlversion.YAML(testInfo())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded map[string]any
+	if err := yaml.Unmarshal(got, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	for key, value := range decoded {
+		if _, ok := value.(string); !ok {
+			t.Errorf("YAML() %s = %#v, want a string", key, value)
+		}
+	}
+
+	for key, want := range map[string]string{"version": "1.20", "cgo_enabled": "true", "hostname": "null"} {
+		if decoded[key] != want {
+			t.Errorf("YAML() %s = %#v, want %q", key, decoded[key], want)
+		}
+	}
+}
+
+func TestYAMLLeavesOutEmptyOptionalFields(t *testing.T) {
+	t.Parallel()
+
+	got, err := yamlversion.YAML(version.Info{Executable: "myapp"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range []string{"cgo_enabled", "executable_path", "hostname", "module_path"} {
+		if bytes.Contains(got, []byte(key+":")) {
+			t.Errorf("YAML() =\n%s\nwant no %s key", got, key)
+		}
+	}
+}